@@ -0,0 +1,2659 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: api/cowboys/v1/cowboys.proto
+
+package cowboysv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Phase int32
+
+const (
+	Phase_PHASE_UNSPECIFIED Phase = 0
+	// The roster is not full yet.
+	Phase_PHASE_WAITING Phase = 1
+	// The master waits for every cowboy to acknowledge its subscription.
+	Phase_PHASE_READY_CHECK Phase = 2
+	Phase_PHASE_COUNTDOWN   Phase = 3
+	Phase_PHASE_FIGHTING    Phase = 4
+	Phase_PHASE_FINISHED    Phase = 5
+)
+
+// Enum value maps for Phase.
+var (
+	Phase_name = map[int32]string{
+		0: "PHASE_UNSPECIFIED",
+		1: "PHASE_WAITING",
+		2: "PHASE_READY_CHECK",
+		3: "PHASE_COUNTDOWN",
+		4: "PHASE_FIGHTING",
+		5: "PHASE_FINISHED",
+	}
+	Phase_value = map[string]int32{
+		"PHASE_UNSPECIFIED": 0,
+		"PHASE_WAITING":     1,
+		"PHASE_READY_CHECK": 2,
+		"PHASE_COUNTDOWN":   3,
+		"PHASE_FIGHTING":    4,
+		"PHASE_FINISHED":    5,
+	}
+)
+
+func (x Phase) Enum() *Phase {
+	p := new(Phase)
+	*p = x
+	return p
+}
+
+func (x Phase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Phase) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_cowboys_v1_cowboys_proto_enumTypes[0].Descriptor()
+}
+
+func (Phase) Type() protoreflect.EnumType {
+	return &file_api_cowboys_v1_cowboys_proto_enumTypes[0]
+}
+
+func (x Phase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Phase.Descriptor instead.
+func (Phase) EnumDescriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{0}
+}
+
+type Cowboy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name   string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Health int32   `protobuf:"varint,3,opt,name=health,proto3" json:"health,omitempty"`
+	Damage int32   `protobuf:"varint,4,opt,name=damage,proto3" json:"damage,omitempty"`
+	Weapon *Weapon `protobuf:"bytes,5,opt,name=weapon,proto3" json:"weapon,omitempty"`
+	// Ammo left in the magazine.
+	Ammo      int32 `protobuf:"varint,6,opt,name=ammo,proto3" json:"ammo,omitempty"`
+	Reloading bool  `protobuf:"varint,7,opt,name=reloading,proto3" json:"reloading,omitempty"`
+	// Speed is the number of shots per second, 0 lets the cowboy shoot once per round.
+	Speed float64 `protobuf:"fixed64,8,opt,name=speed,proto3" json:"speed,omitempty"`
+	// Shots is the number of shots the cowboy can fire in the round.
+	Shots int32 `protobuf:"varint,9,opt,name=shots,proto3" json:"shots,omitempty"`
+	// Position is the tile of the cowboy in a positional game.
+	Position *Position `protobuf:"bytes,10,opt,name=position,proto3" json:"position,omitempty"`
+	// Effects are the status effects the cowboy is under.
+	Effects []*Effect `protobuf:"bytes,11,rep,name=effects,proto3" json:"effects,omitempty"`
+	// Max health is the health the cowboy registered with, which heals can not exceed.
+	MaxHealth int32 `protobuf:"varint,12,opt,name=max_health,json=maxHealth,proto3" json:"max_health,omitempty"`
+	// Heals is the number of heals the cowboy has left.
+	Heals int32 `protobuf:"varint,13,opt,name=heals,proto3" json:"heals,omitempty"`
+	// Allies are the IDs of the cowboys allied with the cowboy.
+	Allies []string `protobuf:"bytes,14,rep,name=allies,proto3" json:"allies,omitempty"`
+}
+
+func (x *Cowboy) Reset() {
+	*x = Cowboy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cowboy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cowboy) ProtoMessage() {}
+
+func (x *Cowboy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cowboy.ProtoReflect.Descriptor instead.
+func (*Cowboy) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cowboy) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Cowboy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cowboy) GetHealth() int32 {
+	if x != nil {
+		return x.Health
+	}
+	return 0
+}
+
+func (x *Cowboy) GetDamage() int32 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+func (x *Cowboy) GetWeapon() *Weapon {
+	if x != nil {
+		return x.Weapon
+	}
+	return nil
+}
+
+func (x *Cowboy) GetAmmo() int32 {
+	if x != nil {
+		return x.Ammo
+	}
+	return 0
+}
+
+func (x *Cowboy) GetReloading() bool {
+	if x != nil {
+		return x.Reloading
+	}
+	return false
+}
+
+func (x *Cowboy) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Cowboy) GetShots() int32 {
+	if x != nil {
+		return x.Shots
+	}
+	return 0
+}
+
+func (x *Cowboy) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Cowboy) GetEffects() []*Effect {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+func (x *Cowboy) GetMaxHealth() int32 {
+	if x != nil {
+		return x.MaxHealth
+	}
+	return 0
+}
+
+func (x *Cowboy) GetHeals() int32 {
+	if x != nil {
+		return x.Heals
+	}
+	return 0
+}
+
+func (x *Cowboy) GetAllies() []string {
+	if x != nil {
+		return x.Allies
+	}
+	return nil
+}
+
+// Effect is a status effect: bleeding, stun, damage_buff or defense_buff.
+type Effect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind      string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Magnitude int32  `protobuf:"varint,2,opt,name=magnitude,proto3" json:"magnitude,omitempty"`
+	// Rounds is the number of round openings the effect lasts.
+	Rounds int32 `protobuf:"varint,3,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	// Stacking is refresh, intensity or ignore, the default of the kind when empty.
+	Stacking string `protobuf:"bytes,4,opt,name=stacking,proto3" json:"stacking,omitempty"`
+	Stacks   int32  `protobuf:"varint,5,opt,name=stacks,proto3" json:"stacks,omitempty"`
+	// Source is the name of the cowboy who applied the effect, empty for an operator.
+	Source string `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *Effect) Reset() {
+	*x = Effect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Effect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Effect) ProtoMessage() {}
+
+func (x *Effect) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Effect.ProtoReflect.Descriptor instead.
+func (*Effect) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Effect) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Effect) GetMagnitude() int32 {
+	if x != nil {
+		return x.Magnitude
+	}
+	return 0
+}
+
+func (x *Effect) GetRounds() int32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *Effect) GetStacking() string {
+	if x != nil {
+		return x.Stacking
+	}
+	return ""
+}
+
+func (x *Effect) GetStacks() int32 {
+	if x != nil {
+		return x.Stacks
+	}
+	return 0
+}
+
+func (x *Effect) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// OnHit is an effect a weapon applies when its shot hits.
+type OnHit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Effect *Effect `protobuf:"bytes,1,opt,name=effect,proto3" json:"effect,omitempty"`
+	// Chance is the probability to apply the effect, 1 when 0.
+	Chance float64 `protobuf:"fixed64,2,opt,name=chance,proto3" json:"chance,omitempty"`
+	// Self applies the effect to the shooter instead of the target.
+	Self bool `protobuf:"varint,3,opt,name=self,proto3" json:"self,omitempty"`
+}
+
+func (x *OnHit) Reset() {
+	*x = OnHit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnHit) ProtoMessage() {}
+
+func (x *OnHit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnHit.ProtoReflect.Descriptor instead.
+func (*OnHit) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OnHit) GetEffect() *Effect {
+	if x != nil {
+		return x.Effect
+	}
+	return nil
+}
+
+func (x *OnHit) GetChance() float64 {
+	if x != nil {
+		return x.Chance
+	}
+	return 0
+}
+
+func (x *OnHit) GetSelf() bool {
+	if x != nil {
+		return x.Self
+	}
+	return false
+}
+
+type Position struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Position) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Position) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// Grid is the battlefield of a positional game.
+type Grid struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Width  int32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Cowboys standing on a cover tile are harder to hit.
+	Cover []*Position `protobuf:"bytes,3,rep,name=cover,proto3" json:"cover,omitempty"`
+}
+
+func (x *Grid) Reset() {
+	*x = Grid{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Grid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grid) ProtoMessage() {}
+
+func (x *Grid) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grid.ProtoReflect.Descriptor instead.
+func (*Grid) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Grid) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Grid) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Grid) GetCover() []*Position {
+	if x != nil {
+		return x.Cover
+	}
+	return nil
+}
+
+type Weapon struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Damage int32  `protobuf:"varint,2,opt,name=damage,proto3" json:"damage,omitempty"`
+	// Magazine is the number of shots before a reload, 0 means the weapon never needs one.
+	Magazine   int32                `protobuf:"varint,3,opt,name=magazine,proto3" json:"magazine,omitempty"`
+	ReloadTime *durationpb.Duration `protobuf:"bytes,4,opt,name=reload_time,json=reloadTime,proto3" json:"reload_time,omitempty"`
+	// Fire rate is the maximum number of shots per second, 0 means unlimited.
+	FireRate float64  `protobuf:"fixed64,5,opt,name=fire_rate,json=fireRate,proto3" json:"fire_rate,omitempty"`
+	Effects  []*OnHit `protobuf:"bytes,6,rep,name=effects,proto3" json:"effects,omitempty"`
+}
+
+func (x *Weapon) Reset() {
+	*x = Weapon{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Weapon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Weapon) ProtoMessage() {}
+
+func (x *Weapon) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Weapon.ProtoReflect.Descriptor instead.
+func (*Weapon) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Weapon) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Weapon) GetDamage() int32 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+func (x *Weapon) GetMagazine() int32 {
+	if x != nil {
+		return x.Magazine
+	}
+	return 0
+}
+
+func (x *Weapon) GetReloadTime() *durationpb.Duration {
+	if x != nil {
+		return x.ReloadTime
+	}
+	return nil
+}
+
+func (x *Weapon) GetFireRate() float64 {
+	if x != nil {
+		return x.FireRate
+	}
+	return 0
+}
+
+func (x *Weapon) GetEffects() []*OnHit {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+type JoinRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Health int32  `protobuf:"varint,2,opt,name=health,proto3" json:"health,omitempty"`
+	Damage int32  `protobuf:"varint,3,opt,name=damage,proto3" json:"damage,omitempty"`
+	// Weapon replaces the damage when set.
+	Weapon *Weapon `protobuf:"bytes,4,opt,name=weapon,proto3" json:"weapon,omitempty"`
+	Speed  float64 `protobuf:"fixed64,5,opt,name=speed,proto3" json:"speed,omitempty"`
+}
+
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequest) ProtoMessage() {}
+
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *JoinRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetHealth() int32 {
+	if x != nil {
+		return x.Health
+	}
+	return 0
+}
+
+func (x *JoinRequest) GetDamage() int32 {
+	if x != nil {
+		return x.Damage
+	}
+	return 0
+}
+
+func (x *JoinRequest) GetWeapon() *Weapon {
+	if x != nil {
+		return x.Weapon
+	}
+	return nil
+}
+
+func (x *JoinRequest) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type JoinResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cowboy *Cowboy `protobuf:"bytes,1,opt,name=cowboy,proto3" json:"cowboy,omitempty"`
+}
+
+func (x *JoinResponse) Reset() {
+	*x = JoinResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinResponse) ProtoMessage() {}
+
+func (x *JoinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinResponse.ProtoReflect.Descriptor instead.
+func (*JoinResponse) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JoinResponse) GetCowboy() *Cowboy {
+	if x != nil {
+		return x.Cowboy
+	}
+	return nil
+}
+
+type GetStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStateRequest) Reset() {
+	*x = GetStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStateRequest) ProtoMessage() {}
+
+func (x *GetStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStateRequest.ProtoReflect.Descriptor instead.
+func (*GetStateRequest) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{8}
+}
+
+type GameState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phase Phase `protobuf:"varint,1,opt,name=phase,proto3,enum=cowboys.v1.Phase" json:"phase,omitempty"`
+	Round int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	// Cowboys still standing.
+	Cowboys []*Cowboy `protobuf:"bytes,3,rep,name=cowboys,proto3" json:"cowboys,omitempty"`
+	// Cowboys eliminated, in the order they died.
+	Eliminated []*Cowboy              `protobuf:"bytes,4,rep,name=eliminated,proto3" json:"eliminated,omitempty"`
+	StartAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+}
+
+func (x *GameState) Reset() {
+	*x = GameState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameState) ProtoMessage() {}
+
+func (x *GameState) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameState.ProtoReflect.Descriptor instead.
+func (*GameState) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GameState) GetPhase() Phase {
+	if x != nil {
+		return x.Phase
+	}
+	return Phase_PHASE_UNSPECIFIED
+}
+
+func (x *GameState) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *GameState) GetCowboys() []*Cowboy {
+	if x != nil {
+		return x.Cowboys
+	}
+	return nil
+}
+
+func (x *GameState) GetEliminated() []*Cowboy {
+	if x != nil {
+		return x.Eliminated
+	}
+	return nil
+}
+
+func (x *GameState) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Journal adds the recorded events, registrations, kills and the result, to the events broadcast to the players.
+	Journal bool `protobuf:"varint,1,opt,name=journal,proto3" json:"journal,omitempty"`
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StreamEventsRequest) GetJournal() bool {
+	if x != nil {
+		return x.Journal
+	}
+	return false
+}
+
+// GameEvent is the envelope of the events exchanged with the master.
+type GameEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type is the event type, like "round" or "shot".
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Json is the event data as exchanged over Redis. The master always sets it, so that clients can
+	// handle event types newer than this contract. A client may leave it empty and set the payload instead.
+	Json []byte `protobuf:"bytes,2,opt,name=json,proto3" json:"json,omitempty"`
+	// Game is the ID of the game, set on the events exchanged over Redis and submitted by the players.
+	Game string `protobuf:"bytes,3,opt,name=game,proto3" json:"game,omitempty"`
+	// Types that are assignable to Payload:
+	//	*GameEvent_Registration
+	//	*GameEvent_Countdown
+	//	*GameEvent_Round
+	//	*GameEvent_Kill
+	//	*GameEvent_Result
+	//	*GameEvent_Ready
+	//	*GameEvent_Shot
+	//	*GameEvent_Reload
+	//	*GameEvent_Message
+	//	*GameEvent_Alliance
+	//	*GameEvent_Betrayal
+	//	*GameEvent_Resync
+	Payload isGameEvent_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *GameEvent) Reset() {
+	*x = GameEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameEvent) ProtoMessage() {}
+
+func (x *GameEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameEvent.ProtoReflect.Descriptor instead.
+func (*GameEvent) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GameEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GameEvent) GetJson() []byte {
+	if x != nil {
+		return x.Json
+	}
+	return nil
+}
+
+func (x *GameEvent) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (m *GameEvent) GetPayload() isGameEvent_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *GameEvent) GetRegistration() *Cowboy {
+	if x, ok := x.GetPayload().(*GameEvent_Registration); ok {
+		return x.Registration
+	}
+	return nil
+}
+
+func (x *GameEvent) GetCountdown() *Countdown {
+	if x, ok := x.GetPayload().(*GameEvent_Countdown); ok {
+		return x.Countdown
+	}
+	return nil
+}
+
+func (x *GameEvent) GetRound() *Round {
+	if x, ok := x.GetPayload().(*GameEvent_Round); ok {
+		return x.Round
+	}
+	return nil
+}
+
+func (x *GameEvent) GetKill() *Kill {
+	if x, ok := x.GetPayload().(*GameEvent_Kill); ok {
+		return x.Kill
+	}
+	return nil
+}
+
+func (x *GameEvent) GetResult() *Result {
+	if x, ok := x.GetPayload().(*GameEvent_Result); ok {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GameEvent) GetReady() *Ready {
+	if x, ok := x.GetPayload().(*GameEvent_Ready); ok {
+		return x.Ready
+	}
+	return nil
+}
+
+func (x *GameEvent) GetShot() *Shot {
+	if x, ok := x.GetPayload().(*GameEvent_Shot); ok {
+		return x.Shot
+	}
+	return nil
+}
+
+func (x *GameEvent) GetReload() *Reload {
+	if x, ok := x.GetPayload().(*GameEvent_Reload); ok {
+		return x.Reload
+	}
+	return nil
+}
+
+func (x *GameEvent) GetMessage() *Message {
+	if x, ok := x.GetPayload().(*GameEvent_Message); ok {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *GameEvent) GetAlliance() *Alliance {
+	if x, ok := x.GetPayload().(*GameEvent_Alliance); ok {
+		return x.Alliance
+	}
+	return nil
+}
+
+func (x *GameEvent) GetBetrayal() *Betrayal {
+	if x, ok := x.GetPayload().(*GameEvent_Betrayal); ok {
+		return x.Betrayal
+	}
+	return nil
+}
+
+func (x *GameEvent) GetResync() *Resync {
+	if x, ok := x.GetPayload().(*GameEvent_Resync); ok {
+		return x.Resync
+	}
+	return nil
+}
+
+type isGameEvent_Payload interface {
+	isGameEvent_Payload()
+}
+
+type GameEvent_Registration struct {
+	Registration *Cowboy `protobuf:"bytes,10,opt,name=registration,proto3,oneof"`
+}
+
+type GameEvent_Countdown struct {
+	Countdown *Countdown `protobuf:"bytes,11,opt,name=countdown,proto3,oneof"`
+}
+
+type GameEvent_Round struct {
+	Round *Round `protobuf:"bytes,12,opt,name=round,proto3,oneof"`
+}
+
+type GameEvent_Kill struct {
+	Kill *Kill `protobuf:"bytes,13,opt,name=kill,proto3,oneof"`
+}
+
+type GameEvent_Result struct {
+	Result *Result `protobuf:"bytes,14,opt,name=result,proto3,oneof"`
+}
+
+type GameEvent_Ready struct {
+	Ready *Ready `protobuf:"bytes,15,opt,name=ready,proto3,oneof"`
+}
+
+type GameEvent_Shot struct {
+	Shot *Shot `protobuf:"bytes,16,opt,name=shot,proto3,oneof"`
+}
+
+type GameEvent_Reload struct {
+	Reload *Reload `protobuf:"bytes,17,opt,name=reload,proto3,oneof"`
+}
+
+type GameEvent_Message struct {
+	Message *Message `protobuf:"bytes,18,opt,name=message,proto3,oneof"`
+}
+
+type GameEvent_Alliance struct {
+	Alliance *Alliance `protobuf:"bytes,19,opt,name=alliance,proto3,oneof"`
+}
+
+type GameEvent_Betrayal struct {
+	Betrayal *Betrayal `protobuf:"bytes,20,opt,name=betrayal,proto3,oneof"`
+}
+
+type GameEvent_Resync struct {
+	Resync *Resync `protobuf:"bytes,21,opt,name=resync,proto3,oneof"`
+}
+
+func (*GameEvent_Registration) isGameEvent_Payload() {}
+
+func (*GameEvent_Countdown) isGameEvent_Payload() {}
+
+func (*GameEvent_Round) isGameEvent_Payload() {}
+
+func (*GameEvent_Kill) isGameEvent_Payload() {}
+
+func (*GameEvent_Result) isGameEvent_Payload() {}
+
+func (*GameEvent_Ready) isGameEvent_Payload() {}
+
+func (*GameEvent_Shot) isGameEvent_Payload() {}
+
+func (*GameEvent_Reload) isGameEvent_Payload() {}
+
+func (*GameEvent_Message) isGameEvent_Payload() {}
+
+func (*GameEvent_Alliance) isGameEvent_Payload() {}
+
+func (*GameEvent_Betrayal) isGameEvent_Payload() {}
+
+func (*GameEvent_Resync) isGameEvent_Payload() {}
+
+type Countdown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	// Server time is the master clock when the event was emitted, to measure the clock offset.
+	ServerTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"`
+}
+
+func (x *Countdown) Reset() {
+	*x = Countdown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Countdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Countdown) ProtoMessage() {}
+
+func (x *Countdown) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Countdown.ProtoReflect.Descriptor instead.
+func (*Countdown) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Countdown) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *Countdown) GetServerTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ServerTime
+	}
+	return nil
+}
+
+type Round struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number           int32     `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Cowboys          []*Cowboy `protobuf:"bytes,2,rep,name=cowboys,proto3" json:"cowboys,omitempty"`
+	DamageMultiplier int32     `protobuf:"varint,3,opt,name=damage_multiplier,json=damageMultiplier,proto3" json:"damage_multiplier,omitempty"`
+	Grid             *Grid     `protobuf:"bytes,4,opt,name=grid,proto3" json:"grid,omitempty"`
+	// A delta round carries only the cowboys that changed since the previous round and the IDs of the removed ones.
+	Delta   bool     `protobuf:"varint,5,opt,name=delta,proto3" json:"delta,omitempty"`
+	Removed []string `protobuf:"bytes,6,rep,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *Round) Reset() {
+	*x = Round{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Round) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Round) ProtoMessage() {}
+
+func (x *Round) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Round.ProtoReflect.Descriptor instead.
+func (*Round) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Round) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Round) GetCowboys() []*Cowboy {
+	if x != nil {
+		return x.Cowboys
+	}
+	return nil
+}
+
+func (x *Round) GetDamageMultiplier() int32 {
+	if x != nil {
+		return x.DamageMultiplier
+	}
+	return 0
+}
+
+func (x *Round) GetGrid() *Grid {
+	if x != nil {
+		return x.Grid
+	}
+	return nil
+}
+
+func (x *Round) GetDelta() bool {
+	if x != nil {
+		return x.Delta
+	}
+	return false
+}
+
+func (x *Round) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+type Kill struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Shooter string `protobuf:"bytes,1,opt,name=shooter,proto3" json:"shooter,omitempty"`
+	Target  string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *Kill) Reset() {
+	*x = Kill{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Kill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Kill) ProtoMessage() {}
+
+func (x *Kill) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Kill.ProtoReflect.Descriptor instead.
+func (*Kill) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Kill) GetShooter() string {
+	if x != nil {
+		return x.Shooter
+	}
+	return ""
+}
+
+func (x *Kill) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Winner    *Cowboy   `protobuf:"bytes,1,opt,name=winner,proto3" json:"winner,omitempty"`
+	Draw      bool      `protobuf:"varint,2,opt,name=draw,proto3" json:"draw,omitempty"`
+	Reason    string    `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Standings []*Cowboy `protobuf:"bytes,4,rep,name=standings,proto3" json:"standings,omitempty"`
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Result) GetWinner() *Cowboy {
+	if x != nil {
+		return x.Winner
+	}
+	return nil
+}
+
+func (x *Result) GetDraw() bool {
+	if x != nil {
+		return x.Draw
+	}
+	return false
+}
+
+func (x *Result) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Result) GetStandings() []*Cowboy {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+type Ready struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *Ready) Reset() {
+	*x = Ready{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ready) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ready) ProtoMessage() {}
+
+func (x *Ready) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ready.ProtoReflect.Descriptor instead.
+func (*Ready) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Ready) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Shot is the action of a cowboy, sent in an "action" event: it shoots at the cowboy to, steps to the adjacent
+// tile move in a positional game, or takes the action of kind.
+type Shot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From  string    `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    string    `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Round int32     `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Move  *Position `protobuf:"bytes,4,opt,name=move,proto3" json:"move,omitempty"`
+	// Kind is shoot, move, dodge, aim, heal or cover, a shot or a move depending on move when empty.
+	Kind string `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+}
+
+func (x *Shot) Reset() {
+	*x = Shot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Shot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shot) ProtoMessage() {}
+
+func (x *Shot) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shot.ProtoReflect.Descriptor instead.
+func (*Shot) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Shot) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Shot) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Shot) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Shot) GetMove() *Position {
+	if x != nil {
+		return x.Move
+	}
+	return nil
+}
+
+func (x *Shot) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type Reload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *Reload) Reset() {
+	*x = Reload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reload) ProtoMessage() {}
+
+func (x *Reload) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reload.ProtoReflect.Descriptor instead.
+func (*Reload) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Reload) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Message is a direct message of a cowboy, relayed by the master to the cowboy to, or to everybody when empty.
+type Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Text string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Message) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Message) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Message) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Alliance is a diplomatic step of the cowboy from towards the cowboy to: propose, accept or break.
+type Alliance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+}
+
+func (x *Alliance) Reset() {
+	*x = Alliance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alliance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alliance) ProtoMessage() {}
+
+func (x *Alliance) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alliance.ProtoReflect.Descriptor instead.
+func (*Alliance) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Alliance) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Alliance) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Alliance) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+// Betrayal records a cowboy shooting at an ally, named like in a kill.
+type Betrayal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Traitor string `protobuf:"bytes,1,opt,name=traitor,proto3" json:"traitor,omitempty"`
+	Victim  string `protobuf:"bytes,2,opt,name=victim,proto3" json:"victim,omitempty"`
+}
+
+func (x *Betrayal) Reset() {
+	*x = Betrayal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Betrayal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Betrayal) ProtoMessage() {}
+
+func (x *Betrayal) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Betrayal.ProtoReflect.Descriptor instead.
+func (*Betrayal) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Betrayal) GetTraitor() string {
+	if x != nil {
+		return x.Traitor
+	}
+	return ""
+}
+
+func (x *Betrayal) GetVictim() string {
+	if x != nil {
+		return x.Victim
+	}
+	return ""
+}
+
+// Resync asks the master for a full snapshot after the cowboy missed the rounds following last.
+type Resync struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Last int32  `protobuf:"varint,2,opt,name=last,proto3" json:"last,omitempty"`
+}
+
+func (x *Resync) Reset() {
+	*x = Resync{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Resync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resync) ProtoMessage() {}
+
+func (x *Resync) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resync.ProtoReflect.Descriptor instead.
+func (*Resync) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Resync) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Resync) GetLast() int32 {
+	if x != nil {
+		return x.Last
+	}
+	return 0
+}
+
+type SubmitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SubmitResponse) Reset() {
+	*x = SubmitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitResponse) ProtoMessage() {}
+
+func (x *SubmitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitResponse.ProtoReflect.Descriptor instead.
+func (*SubmitResponse) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{23}
+}
+
+type AbortRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *AbortRequest) Reset() {
+	*x = AbortRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortRequest) ProtoMessage() {}
+
+func (x *AbortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortRequest.ProtoReflect.Descriptor instead.
+func (*AbortRequest) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AbortRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AbortResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AbortResponse) Reset() {
+	*x = AbortResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortResponse) ProtoMessage() {}
+
+func (x *AbortResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_cowboys_v1_cowboys_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortResponse.ProtoReflect.Descriptor instead.
+func (*AbortResponse) Descriptor() ([]byte, []int) {
+	return file_api_cowboys_v1_cowboys_proto_rawDescGZIP(), []int{25}
+}
+
+var File_api_cowboys_v1_cowboys_proto protoreflect.FileDescriptor
+
+var file_api_cowboys_v1_cowboys_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2f, 0x76, 0x31,
+	0x2f, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a,
+	0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x93, 0x03, 0x0a, 0x06,
+	0x43, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x77, 0x65,
+	0x61, 0x70, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77,
+	0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x52, 0x06,
+	0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x6d, 0x6d, 0x6f, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x61, 0x6d, 0x6d, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x6c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72,
+	0x65, 0x6c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73,
+	0x68, 0x6f, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x07, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x52, 0x07, 0x65, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x65, 0x61, 0x6c, 0x73, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x68, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6c, 0x6c,
+	0x69, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6c, 0x6c, 0x69, 0x65,
+	0x73, 0x22, 0x9e, 0x01, 0x0a, 0x06, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x67, 0x6e, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x67, 0x6e, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x69,
+	0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x69,
+	0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x22, 0x5f, 0x0a, 0x05, 0x4f, 0x6e, 0x48, 0x69, 0x74, 0x12, 0x2a, 0x0a, 0x06, 0x65,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f,
+	0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x52,
+	0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x65, 0x6c, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73,
+	0x65, 0x6c, 0x66, 0x22, 0x26, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a,
+	0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x22, 0x60, 0x0a, 0x04, 0x47,
+	0x72, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x2a, 0x0a, 0x05, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x22, 0xd6, 0x01,
+	0x0a, 0x06, 0x57, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x64, 0x61,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x67, 0x61, 0x7a, 0x69, 0x6e, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x67, 0x61, 0x7a, 0x69, 0x6e, 0x65,
+	0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0a, 0x72, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x66, 0x69, 0x72, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x66, 0x69, 0x72, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x65, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x6f, 0x77,
+	0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x6e, 0x48, 0x69, 0x74, 0x52, 0x07, 0x65,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x0b, 0x4a, 0x6f, 0x69, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x77, 0x65,
+	0x61, 0x70, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77,
+	0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x52, 0x06,
+	0x77, 0x65, 0x61, 0x70, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x3a, 0x0a, 0x0c,
+	0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06,
+	0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63,
+	0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x77, 0x62, 0x6f, 0x79,
+	0x52, 0x06, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xe3, 0x01, 0x0a, 0x09,
+	0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x70, 0x68, 0x61,
+	0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f,
+	0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61,
+	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x6f, 0x77, 0x62,
+	0x6f, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77, 0x62,
+	0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x52, 0x07, 0x63,
+	0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x6e,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77,
+	0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x52, 0x0a,
+	0x65, 0x6c, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41,
+	0x74, 0x22, 0x2f, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6a, 0x6f, 0x75, 0x72,
+	0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6a, 0x6f, 0x75, 0x72, 0x6e,
+	0x61, 0x6c, 0x22, 0x8c, 0x05, 0x0a, 0x09, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0c,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x64,
+	0x6f, 0x77, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x77, 0x62,
+	0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x64, 0x6f, 0x77, 0x6e,
+	0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x29, 0x0a,
+	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63,
+	0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x48,
+	0x00, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x26, 0x0a, 0x04, 0x6b, 0x69, 0x6c, 0x6c,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x69, 0x6c, 0x6c, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69, 0x6c, 0x6c,
+	0x12, 0x2c, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x29,
+	0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79,
+	0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x26, 0x0a, 0x04, 0x73, 0x68, 0x6f,
+	0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x04, 0x73, 0x68, 0x6f,
+	0x74, 0x12, 0x2c, 0x0a, 0x06, 0x72, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x2f, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x32, 0x0a, 0x08, 0x61, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x08, 0x61, 0x6c, 0x6c, 0x69,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x62, 0x65, 0x74, 0x72, 0x61, 0x79, 0x61, 0x6c,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x65, 0x74, 0x72, 0x61, 0x79, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x08,
+	0x62, 0x65, 0x74, 0x72, 0x61, 0x79, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x79,
+	0x6e, 0x63, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f,
+	0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x48, 0x00, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x22, 0x7f, 0x0a, 0x09, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x35,
+	0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x41, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0xd0, 0x01, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x52, 0x07, 0x63, 0x6f, 0x77, 0x62, 0x6f,
+	0x79, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x64,
+	0x61, 0x6d, 0x61, 0x67, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x12,
+	0x24, 0x0a, 0x04, 0x67, 0x72, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x69, 0x64, 0x52,
+	0x04, 0x67, 0x72, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x38, 0x0a, 0x04, 0x4b, 0x69, 0x6c, 0x6c, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x68, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x68, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22,
+	0x92, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2a, 0x0a, 0x06, 0x77, 0x69,
+	0x6e, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77,
+	0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x52, 0x06,
+	0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x72, 0x61, 0x77, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x72, 0x61, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x52, 0x09, 0x73, 0x74, 0x61, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x22, 0x17, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x7e, 0x0a,
+	0x04, 0x53, 0x68, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x28, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x18, 0x0a,
+	0x06, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x41, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x42, 0x0a, 0x08, 0x41, 0x6c,
+	0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x3c,
+	0x0a, 0x08, 0x42, 0x65, 0x74, 0x72, 0x61, 0x79, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72,
+	0x61, 0x69, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x72, 0x61,
+	0x69, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6d, 0x22, 0x2c, 0x0a, 0x06,
+	0x52, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x22, 0x10, 0x0a, 0x0e, 0x53, 0x75,
+	0x62, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x26, 0x0a, 0x0c,
+	0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x22, 0x0f, 0x0a, 0x0d, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x85, 0x01, 0x0a, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x12,
+	0x15, 0x0a, 0x11, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f,
+	0x57, 0x41, 0x49, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x48, 0x41,
+	0x53, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x10, 0x02,
+	0x12, 0x13, 0x0a, 0x0f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x44,
+	0x4f, 0x57, 0x4e, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x46,
+	0x49, 0x47, 0x48, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x48, 0x41,
+	0x53, 0x45, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x05, 0x32, 0xc7, 0x02,
+	0x0a, 0x05, 0x41, 0x72, 0x65, 0x6e, 0x61, 0x12, 0x39, 0x0a, 0x04, 0x4a, 0x6f, 0x69, 0x6e, 0x12,
+	0x17, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x69,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f,
+	0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b,
+	0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6f,
+	0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x48, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x1f, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x06,
+	0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x12, 0x15, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x1a, 0x2e,
+	0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x05, 0x41, 0x62, 0x6f,
+	0x72, 0x74, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63,
+	0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x60, 0x0a, 0x22, 0x63, 0x6f, 0x6d, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x6a, 0x73,
+	0x6f, 0x6e, 0x2e, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a,
+	0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x65, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x6a, 0x73, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x2f, 0x76, 0x31, 0x3b,
+	0x63, 0x6f, 0x77, 0x62, 0x6f, 0x79, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_api_cowboys_v1_cowboys_proto_rawDescOnce sync.Once
+	file_api_cowboys_v1_cowboys_proto_rawDescData = file_api_cowboys_v1_cowboys_proto_rawDesc
+)
+
+func file_api_cowboys_v1_cowboys_proto_rawDescGZIP() []byte {
+	file_api_cowboys_v1_cowboys_proto_rawDescOnce.Do(func() {
+		file_api_cowboys_v1_cowboys_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_cowboys_v1_cowboys_proto_rawDescData)
+	})
+	return file_api_cowboys_v1_cowboys_proto_rawDescData
+}
+
+var file_api_cowboys_v1_cowboys_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_cowboys_v1_cowboys_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_api_cowboys_v1_cowboys_proto_goTypes = []interface{}{
+	(Phase)(0),                    // 0: cowboys.v1.Phase
+	(*Cowboy)(nil),                // 1: cowboys.v1.Cowboy
+	(*Effect)(nil),                // 2: cowboys.v1.Effect
+	(*OnHit)(nil),                 // 3: cowboys.v1.OnHit
+	(*Position)(nil),              // 4: cowboys.v1.Position
+	(*Grid)(nil),                  // 5: cowboys.v1.Grid
+	(*Weapon)(nil),                // 6: cowboys.v1.Weapon
+	(*JoinRequest)(nil),           // 7: cowboys.v1.JoinRequest
+	(*JoinResponse)(nil),          // 8: cowboys.v1.JoinResponse
+	(*GetStateRequest)(nil),       // 9: cowboys.v1.GetStateRequest
+	(*GameState)(nil),             // 10: cowboys.v1.GameState
+	(*StreamEventsRequest)(nil),   // 11: cowboys.v1.StreamEventsRequest
+	(*GameEvent)(nil),             // 12: cowboys.v1.GameEvent
+	(*Countdown)(nil),             // 13: cowboys.v1.Countdown
+	(*Round)(nil),                 // 14: cowboys.v1.Round
+	(*Kill)(nil),                  // 15: cowboys.v1.Kill
+	(*Result)(nil),                // 16: cowboys.v1.Result
+	(*Ready)(nil),                 // 17: cowboys.v1.Ready
+	(*Shot)(nil),                  // 18: cowboys.v1.Shot
+	(*Reload)(nil),                // 19: cowboys.v1.Reload
+	(*Message)(nil),               // 20: cowboys.v1.Message
+	(*Alliance)(nil),              // 21: cowboys.v1.Alliance
+	(*Betrayal)(nil),              // 22: cowboys.v1.Betrayal
+	(*Resync)(nil),                // 23: cowboys.v1.Resync
+	(*SubmitResponse)(nil),        // 24: cowboys.v1.SubmitResponse
+	(*AbortRequest)(nil),          // 25: cowboys.v1.AbortRequest
+	(*AbortResponse)(nil),         // 26: cowboys.v1.AbortResponse
+	(*durationpb.Duration)(nil),   // 27: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 28: google.protobuf.Timestamp
+}
+var file_api_cowboys_v1_cowboys_proto_depIdxs = []int32{
+	6,  // 0: cowboys.v1.Cowboy.weapon:type_name -> cowboys.v1.Weapon
+	4,  // 1: cowboys.v1.Cowboy.position:type_name -> cowboys.v1.Position
+	2,  // 2: cowboys.v1.Cowboy.effects:type_name -> cowboys.v1.Effect
+	2,  // 3: cowboys.v1.OnHit.effect:type_name -> cowboys.v1.Effect
+	4,  // 4: cowboys.v1.Grid.cover:type_name -> cowboys.v1.Position
+	27, // 5: cowboys.v1.Weapon.reload_time:type_name -> google.protobuf.Duration
+	3,  // 6: cowboys.v1.Weapon.effects:type_name -> cowboys.v1.OnHit
+	6,  // 7: cowboys.v1.JoinRequest.weapon:type_name -> cowboys.v1.Weapon
+	1,  // 8: cowboys.v1.JoinResponse.cowboy:type_name -> cowboys.v1.Cowboy
+	0,  // 9: cowboys.v1.GameState.phase:type_name -> cowboys.v1.Phase
+	1,  // 10: cowboys.v1.GameState.cowboys:type_name -> cowboys.v1.Cowboy
+	1,  // 11: cowboys.v1.GameState.eliminated:type_name -> cowboys.v1.Cowboy
+	28, // 12: cowboys.v1.GameState.start_at:type_name -> google.protobuf.Timestamp
+	1,  // 13: cowboys.v1.GameEvent.registration:type_name -> cowboys.v1.Cowboy
+	13, // 14: cowboys.v1.GameEvent.countdown:type_name -> cowboys.v1.Countdown
+	14, // 15: cowboys.v1.GameEvent.round:type_name -> cowboys.v1.Round
+	15, // 16: cowboys.v1.GameEvent.kill:type_name -> cowboys.v1.Kill
+	16, // 17: cowboys.v1.GameEvent.result:type_name -> cowboys.v1.Result
+	17, // 18: cowboys.v1.GameEvent.ready:type_name -> cowboys.v1.Ready
+	18, // 19: cowboys.v1.GameEvent.shot:type_name -> cowboys.v1.Shot
+	19, // 20: cowboys.v1.GameEvent.reload:type_name -> cowboys.v1.Reload
+	20, // 21: cowboys.v1.GameEvent.message:type_name -> cowboys.v1.Message
+	21, // 22: cowboys.v1.GameEvent.alliance:type_name -> cowboys.v1.Alliance
+	22, // 23: cowboys.v1.GameEvent.betrayal:type_name -> cowboys.v1.Betrayal
+	23, // 24: cowboys.v1.GameEvent.resync:type_name -> cowboys.v1.Resync
+	28, // 25: cowboys.v1.Countdown.start_at:type_name -> google.protobuf.Timestamp
+	28, // 26: cowboys.v1.Countdown.server_time:type_name -> google.protobuf.Timestamp
+	1,  // 27: cowboys.v1.Round.cowboys:type_name -> cowboys.v1.Cowboy
+	5,  // 28: cowboys.v1.Round.grid:type_name -> cowboys.v1.Grid
+	1,  // 29: cowboys.v1.Result.winner:type_name -> cowboys.v1.Cowboy
+	1,  // 30: cowboys.v1.Result.standings:type_name -> cowboys.v1.Cowboy
+	4,  // 31: cowboys.v1.Shot.move:type_name -> cowboys.v1.Position
+	7,  // 32: cowboys.v1.Arena.Join:input_type -> cowboys.v1.JoinRequest
+	9,  // 33: cowboys.v1.Arena.GetState:input_type -> cowboys.v1.GetStateRequest
+	11, // 34: cowboys.v1.Arena.StreamEvents:input_type -> cowboys.v1.StreamEventsRequest
+	12, // 35: cowboys.v1.Arena.Submit:input_type -> cowboys.v1.GameEvent
+	25, // 36: cowboys.v1.Arena.Abort:input_type -> cowboys.v1.AbortRequest
+	8,  // 37: cowboys.v1.Arena.Join:output_type -> cowboys.v1.JoinResponse
+	10, // 38: cowboys.v1.Arena.GetState:output_type -> cowboys.v1.GameState
+	12, // 39: cowboys.v1.Arena.StreamEvents:output_type -> cowboys.v1.GameEvent
+	24, // 40: cowboys.v1.Arena.Submit:output_type -> cowboys.v1.SubmitResponse
+	26, // 41: cowboys.v1.Arena.Abort:output_type -> cowboys.v1.AbortResponse
+	37, // [37:42] is the sub-list for method output_type
+	32, // [32:37] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
+}
+
+func init() { file_api_cowboys_v1_cowboys_proto_init() }
+func file_api_cowboys_v1_cowboys_proto_init() {
+	if File_api_cowboys_v1_cowboys_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_cowboys_v1_cowboys_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cowboy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Effect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnHit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Position); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Grid); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Weapon); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Countdown); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Round); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Kill); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ready); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Shot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alliance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Betrayal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resync); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_cowboys_v1_cowboys_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_api_cowboys_v1_cowboys_proto_msgTypes[11].OneofWrappers = []interface{}{
+		(*GameEvent_Registration)(nil),
+		(*GameEvent_Countdown)(nil),
+		(*GameEvent_Round)(nil),
+		(*GameEvent_Kill)(nil),
+		(*GameEvent_Result)(nil),
+		(*GameEvent_Ready)(nil),
+		(*GameEvent_Shot)(nil),
+		(*GameEvent_Reload)(nil),
+		(*GameEvent_Message)(nil),
+		(*GameEvent_Alliance)(nil),
+		(*GameEvent_Betrayal)(nil),
+		(*GameEvent_Resync)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_cowboys_v1_cowboys_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_cowboys_v1_cowboys_proto_goTypes,
+		DependencyIndexes: file_api_cowboys_v1_cowboys_proto_depIdxs,
+		EnumInfos:         file_api_cowboys_v1_cowboys_proto_enumTypes,
+		MessageInfos:      file_api_cowboys_v1_cowboys_proto_msgTypes,
+	}.Build()
+	File_api_cowboys_v1_cowboys_proto = out.File
+	file_api_cowboys_v1_cowboys_proto_rawDesc = nil
+	file_api_cowboys_v1_cowboys_proto_goTypes = nil
+	file_api_cowboys_v1_cowboys_proto_depIdxs = nil
+}
@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/cowboys/v1/cowboys.proto
+
+package cowboysv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Arena_Join_FullMethodName         = "/cowboys.v1.Arena/Join"
+	Arena_GetState_FullMethodName     = "/cowboys.v1.Arena/GetState"
+	Arena_StreamEvents_FullMethodName = "/cowboys.v1.Arena/StreamEvents"
+	Arena_Submit_FullMethodName       = "/cowboys.v1.Arena/Submit"
+	Arena_Abort_FullMethodName        = "/cowboys.v1.Arena/Abort"
+)
+
+// ArenaClient is the client API for Arena service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ArenaClient interface {
+	// Join registers a cowboy while the roster is not full.
+	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+	// GetState returns a snapshot of the game.
+	GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GameState, error)
+	// StreamEvents streams the game events until the game is over.
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (Arena_StreamEventsClient, error)
+	// Submit sends a player event to the master, like a ready acknowledgement or a shot.
+	Submit(ctx context.Context, in *GameEvent, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// Abort ends the game without a winner.
+	Abort(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*AbortResponse, error)
+}
+
+type arenaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewArenaClient(cc grpc.ClientConnInterface) ArenaClient {
+	return &arenaClient{cc}
+}
+
+func (c *arenaClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	out := new(JoinResponse)
+	err := c.cc.Invoke(ctx, Arena_Join_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arenaClient) GetState(ctx context.Context, in *GetStateRequest, opts ...grpc.CallOption) (*GameState, error) {
+	out := new(GameState)
+	err := c.cc.Invoke(ctx, Arena_GetState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arenaClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (Arena_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Arena_ServiceDesc.Streams[0], Arena_StreamEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &arenaStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Arena_StreamEventsClient interface {
+	Recv() (*GameEvent, error)
+	grpc.ClientStream
+}
+
+type arenaStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *arenaStreamEventsClient) Recv() (*GameEvent, error) {
+	m := new(GameEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *arenaClient) Submit(ctx context.Context, in *GameEvent, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, Arena_Submit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arenaClient) Abort(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*AbortResponse, error) {
+	out := new(AbortResponse)
+	err := c.cc.Invoke(ctx, Arena_Abort_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ArenaServer is the server API for Arena service.
+// All implementations must embed UnimplementedArenaServer
+// for forward compatibility
+type ArenaServer interface {
+	// Join registers a cowboy while the roster is not full.
+	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	// GetState returns a snapshot of the game.
+	GetState(context.Context, *GetStateRequest) (*GameState, error)
+	// StreamEvents streams the game events until the game is over.
+	StreamEvents(*StreamEventsRequest, Arena_StreamEventsServer) error
+	// Submit sends a player event to the master, like a ready acknowledgement or a shot.
+	Submit(context.Context, *GameEvent) (*SubmitResponse, error)
+	// Abort ends the game without a winner.
+	Abort(context.Context, *AbortRequest) (*AbortResponse, error)
+	mustEmbedUnimplementedArenaServer()
+}
+
+// UnimplementedArenaServer must be embedded to have forward compatible implementations.
+type UnimplementedArenaServer struct {
+}
+
+func (UnimplementedArenaServer) Join(context.Context, *JoinRequest) (*JoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (UnimplementedArenaServer) GetState(context.Context, *GetStateRequest) (*GameState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedArenaServer) StreamEvents(*StreamEventsRequest, Arena_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedArenaServer) Submit(context.Context, *GameEvent) (*SubmitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Submit not implemented")
+}
+func (UnimplementedArenaServer) Abort(context.Context, *AbortRequest) (*AbortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Abort not implemented")
+}
+func (UnimplementedArenaServer) mustEmbedUnimplementedArenaServer() {}
+
+// UnsafeArenaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArenaServer will
+// result in compilation errors.
+type UnsafeArenaServer interface {
+	mustEmbedUnimplementedArenaServer()
+}
+
+func RegisterArenaServer(s grpc.ServiceRegistrar, srv ArenaServer) {
+	s.RegisterService(&Arena_ServiceDesc, srv)
+}
+
+func _Arena_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArenaServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Arena_Join_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArenaServer).Join(ctx, req.(*JoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Arena_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArenaServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Arena_GetState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArenaServer).GetState(ctx, req.(*GetStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Arena_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArenaServer).StreamEvents(m, &arenaStreamEventsServer{stream})
+}
+
+type Arena_StreamEventsServer interface {
+	Send(*GameEvent) error
+	grpc.ServerStream
+}
+
+type arenaStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *arenaStreamEventsServer) Send(m *GameEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Arena_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GameEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArenaServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Arena_Submit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArenaServer).Submit(ctx, req.(*GameEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Arena_Abort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArenaServer).Abort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Arena_Abort_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArenaServer).Abort(ctx, req.(*AbortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Arena_ServiceDesc is the grpc.ServiceDesc for Arena service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Arena_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cowboys.v1.Arena",
+	HandlerType: (*ArenaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Join",
+			Handler:    _Arena_Join_Handler,
+		},
+		{
+			MethodName: "GetState",
+			Handler:    _Arena_GetState_Handler,
+		},
+		{
+			MethodName: "Submit",
+			Handler:    _Arena_Submit_Handler,
+		},
+		{
+			MethodName: "Abort",
+			Handler:    _Arena_Abort_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _Arena_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/cowboys/v1/cowboys.proto",
+}
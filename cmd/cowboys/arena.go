@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/arena"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/roster"
+)
+
+// Exit codes of the commands. exitOK ends the commands that do not play a game.
+const (
+	exitOK       = 0
+	exitGameOver = 0
+	exitFailure  = 1
+	exitAborted  = 3
+)
+
+func runArena(args []string) int {
+	flags := flag.NewFlagSet("arena", flag.ExitOnError)
+	players := flags.String("players", "players.json", "roster of the cowboys")
+	port := flags.String("port", ":8080", "HTTP address of the master")
+	redisAddr := flags.String("redis", "localhost:6379", "address of Redis")
+	masterBin := flags.String("master-bin", "", "master executable, next to cowboys or in the PATH by default")
+	playerBin := flags.String("player-bin", "", "player executable, next to cowboys or in the PATH by default")
+	restart := flags.String("restart", string(arena.RestartOnFailure), "restart policy of crashed players: never or on-failure")
+	maxRestarts := flags.Int("max-restarts", 3, "restarts of a crashed player before giving up")
+	_ = flags.Parse(args)
+
+	logger := log.New(os.Stderr, "arena: ", log.LstdFlags)
+
+	if !arena.RestartPolicy(*restart).Valid() {
+		logger.Printf("unknown restart policy %q", *restart)
+		return exitFailure
+	}
+
+	entries, err := roster.Load(*players)
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+
+	cfg := &arena.Config{
+		Roster:       entries,
+		MasterBin:    executable(*masterBin, "master"),
+		PlayerBin:    executable(*playerBin, "player"),
+		Port:         *port,
+		Restart:      arena.RestartPolicy(*restart),
+		MaxRestarts:  *maxRestarts,
+		Env:          append(os.Environ(), "REDIS_ADDR="+*redisAddr),
+		Out:          os.Stdout,
+		StartTimeout: 10 * time.Second,
+		StopTimeout:  5 * time.Second,
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	result, err := arena.New(cfg, logger).Run(ctx)
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+
+	return report(logger, result)
+}
+
+// report logs the result and returns the exit code matching it.
+func report(logger *log.Logger, result *domain.Result) int {
+	switch {
+	case result.Winner != nil:
+		logger.Printf("%s wins with %d health", result.Winner.Name, result.Winner.Health)
+	case result.Draw:
+		logger.Print("draw, nobody is standing")
+	default:
+		logger.Printf("game aborted: %s", result.Reason)
+		return exitAborted
+	}
+
+	for i, player := range result.Standings {
+		logger.Printf("%d. %s", i+1, player.Name)
+	}
+
+	return exitGameOver
+}
+
+// executable resolves the binary of a component: the flag, the file next to cowboys, or the PATH.
+func executable(flagValue, name string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+
+	if self, err := os.Executable(); err == nil {
+		sibling := filepath.Join(filepath.Dir(self), name)
+		if _, err := os.Stat(sibling); err == nil {
+			return sibling
+		}
+	}
+
+	if path, err := exec.LookPath(name); err == nil {
+		return path
+	}
+
+	return name
+}
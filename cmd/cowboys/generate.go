@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/reactivejson/cowboys/internal/generate"
+	"github.com/reactivejson/cowboys/internal/roster"
+)
+
+func runGenerate(args []string) int {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	players := flags.String("players", "players.json", "roster of the cowboys")
+	out := flags.String("out", ".", "directory to generate the files in")
+	registry := flags.String("registry", "", "registry of the images of the Kubernetes manifests, e.g. 127.0.0.1:5000")
+	tag := flags.String("tag", "latest", "tag of the images of the Kubernetes manifests")
+	_ = flags.Parse(args)
+
+	logger := log.New(os.Stderr, "generate: ", 0)
+
+	entries, err := roster.Load(*players)
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+
+	prefix := *registry
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	files, err := generate.Generate(entries, &generate.Options{
+		Source:   filepath.Base(*players),
+		Out:      *out,
+		Registry: prefix,
+		Tag:      *tag,
+	})
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file.Path), 0o755); err != nil {
+			logger.Print(err)
+			return exitFailure
+		}
+
+		if err := os.WriteFile(file.Path, file.Content, 0o644); err != nil {
+			logger.Print(err)
+			return exitFailure
+		}
+
+		logger.Printf("wrote %s", file.Path)
+	}
+
+	return exitOK
+}
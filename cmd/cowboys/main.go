@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+/**
+ * @author Mohamed-Aly Bou-Hanane
+ * © 2023
+ */
+
+const usage = `Usage: cowboys <command> [flags]
+
+Commands:
+  arena     run a master and one player process per cowboy of a roster
+  generate  generate the docker compose file, helm values and Kubernetes manifests of a roster
+  simulate  play a roster offline on a virtual clock
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "arena":
+		os.Exit(runArena(os.Args[2:]))
+	case "generate":
+		os.Exit(runGenerate(os.Args[2:]))
+	case "simulate":
+		os.Exit(runSimulate(os.Args[2:]))
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/roster"
+	"github.com/reactivejson/cowboys/internal/strategy"
+)
+
+func runSimulate(args []string) int {
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	players := flags.String("players", "players.json", "roster of the cowboys")
+	strategyName := flags.String("strategy", strategy.NameRandom, "strategy of every cowboy: random, weakest, nearest or cautious")
+	seed := flags.Int64("seed", 1, "seed of the random strategy and of the positional game")
+	size := flags.String("arena", "", "size of the grid of a positional game, e.g. 10x10")
+	cover := flags.String("cover", "", "comma separated cover tiles of the grid, e.g. 3:4,5:5")
+	maxRounds := flags.Int("max-rounds", 10000, "rounds before the game is aborted, 0 means no limit")
+	stallRounds := flags.Int("stall-rounds", 0, "rounds without damage before the stall policy applies, 0 disables it")
+	stallPolicy := flags.String("stall-policy", string(domain.StallAbort), "stall policy: wait, highest_health, sudden_death or abort")
+	shotCooldown := flags.Duration("shot-cooldown", time.Second, "delay between two shots of a cowboy without a speed")
+	verbose := flags.Bool("v", false, "log every shot")
+	_ = flags.Parse(args)
+
+	logger := log.New(os.Stderr, "simulate: ", 0)
+
+	if !domain.StallPolicy(*stallPolicy).Valid() {
+		logger.Printf("unknown stall policy %q", *stallPolicy)
+		return exitFailure
+	}
+
+	if *shotCooldown <= 0 {
+		logger.Printf("the shot cooldown must be positive, got %s", *shotCooldown)
+		return exitFailure
+	}
+
+	var tiles []string
+	if *cover != "" {
+		tiles = strings.Split(*cover, ",")
+	}
+
+	grid, err := domain.ParseGrid(*size, tiles)
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+
+	targeter, err := strategy.New(*strategyName)
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+
+	if *strategyName == strategy.NameRandom {
+		targeter = strategy.NewRandom(*seed)
+	}
+
+	entries, err := roster.Load(*players)
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+
+	cowboys := make([]*domain.Player, 0, len(entries.Players))
+	for _, entry := range entries.Players {
+		cowboys = append(cowboys, entry.Player())
+	}
+
+	// The game logs every shot with the standard logger.
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	simulation, err := game.Simulate(ctx, &game.SimulationConfig{
+		Players:      cowboys,
+		Targeter:     targeter,
+		ShotCooldown: *shotCooldown,
+		MaxRounds:    *maxRounds,
+		StallRounds:  *stallRounds,
+		StallPolicy:  domain.StallPolicy(*stallPolicy),
+		Grid:         grid,
+		Seed:         *seed,
+	})
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+
+	logger.Printf("%d rounds in %s of game time", simulation.Rounds, simulation.Elapsed)
+
+	return report(logger, simulation.Result)
+}
@@ -19,6 +19,7 @@ func Setup(ctx context.Context, cfg *Contx) error {
 	setupFuncs := []setupFn{
 		setupLog(),
 		setupRedis(),
+		setupTransport(),
 		setupMasterService(),
 	}
 	return runSetupFncs(setupFuncs, cfg)
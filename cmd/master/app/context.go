@@ -4,6 +4,7 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/reactivejson/cowboys/internal/app"
 	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/transport"
 	"log"
 )
 
@@ -17,7 +18,8 @@ type Contx struct {
 	Closers       []func()
 	log           *log.Logger
 	cfg           *domain.MasterConfig
-	redis         *redis.Client
+	redis         redis.UniversalClient
+	transport     transport.Transport
 	masterService *app.Master
 }
 
@@ -2,11 +2,13 @@ package app
 
 import (
 	"fmt"
-	"github.com/go-redis/redis/v8"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/reactivejson/cowboys/internal/app"
+	"github.com/reactivejson/cowboys/internal/clock"
+	"github.com/reactivejson/cowboys/internal/codec"
 	"github.com/reactivejson/cowboys/internal/domain"
 	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/transport"
 	"log"
 )
 
@@ -29,7 +31,7 @@ func SetupEnvConfig() *domain.MasterConfig {
 
 	cfg := &domain.MasterConfig{}
 	if err := envconfig.Process("", cfg); err != nil {
-		fmt.Errorf("could not parse config: %w", err)
+		log.Fatalf("could not parse config: %v", err)
 	}
 	return cfg
 }
@@ -37,10 +39,51 @@ func SetupEnvConfig() *domain.MasterConfig {
 func setupRedis() setupFn {
 	return func(c *Contx) (err error) {
 		if c.redis == nil {
-			options := &redis.Options{
-				Addr: c.cfg.RedisAddr,
+			c.redis, err = transport.NewRedisClient(&c.cfg.RedisConfig)
+			if err != nil {
+				return fmt.Errorf("setup redis: %w", err)
+			}
+
+			c.Closers = append(c.Closers, func() {
+				if err := c.redis.Close(); err != nil {
+					c.log.Printf("close redis client: %v", err)
+				}
+			})
+		}
+		return nil
+	}
+}
+
+func setupTransport() setupFn {
+	return func(c *Contx) (err error) {
+		if c.transport == nil {
+			if err := c.cfg.ValidateKeys(); err != nil {
+				return fmt.Errorf("setup transport: %w", err)
+			}
+
+			if _, err := codec.New(c.cfg.Codec); err != nil {
+				return fmt.Errorf("setup transport: %w", err)
+			}
+
+			if err := c.cfg.OutboxConfig.Validate(); err != nil {
+				return fmt.Errorf("setup transport: %w", err)
+			}
+
+			if err := c.cfg.SubscriptionConfig.Validate(); err != nil {
+				return fmt.Errorf("setup transport: %w", err)
+			}
+
+			c.transport = transport.NewRedis(c.redis)
+
+			if c.cfg.Chaos != "" {
+				chaos, err := transport.ParseChaosConfig(c.cfg.Chaos)
+				if err != nil {
+					return fmt.Errorf("setup chaos transport: %w", err)
+				}
+
+				c.log.Printf("injecting faults into the transport: %s", c.cfg.Chaos)
+				c.transport = transport.NewChaos(c.transport, chaos, c.log)
 			}
-			c.redis = redis.NewClient(options)
 		}
 		return nil
 	}
@@ -49,8 +92,32 @@ func setupRedis() setupFn {
 func setupMasterService() setupFn {
 	return func(c *Contx) (err error) {
 		if c.masterService == nil {
+			if !c.cfg.StallPolicy.Valid() {
+				return fmt.Errorf("unknown stall policy %q", c.cfg.StallPolicy)
+			}
+
+			if _, err := c.cfg.Grid(); err != nil {
+				return fmt.Errorf("setup arena: %w", err)
+			}
+
+			if c.cfg.HealPoints < 0 || c.cfg.Heals < 0 {
+				return fmt.Errorf("heal points and heals can not be negative")
+			}
+
+			if c.cfg.SnapshotEvery < 0 {
+				return fmt.Errorf("snapshot interval can not be negative")
+			}
+
+			if c.cfg.ShotCooldown < 0 {
+				return fmt.Errorf("shot cooldown can not be negative")
+			}
+
+			if c.cfg.ReadyTimeout < 0 {
+				return fmt.Errorf("ready timeout can not be negative")
+			}
+
 			state := game.NewGame(c.cfg)
-			c.masterService = app.NewMaster(c.cfg, state, c.log, c.redis)
+			c.masterService = app.NewMaster(c.cfg, state, clock.Real{}, c.log, c.transport)
 			c.masterService.Run()
 		}
 		return nil
@@ -19,6 +19,8 @@ func Setup(ctx context.Context, cfg *Contx) error {
 	setupFuncs := []setupFn{
 		setupLog(),
 		setupRedis(),
+		setupTransport(),
+		setupStrategy(),
 		setupPlayerService(),
 	}
 	return runSetupFncs(setupFuncs, cfg)
@@ -4,6 +4,8 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/reactivejson/cowboys/internal/app"
 	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/strategy"
+	"github.com/reactivejson/cowboys/internal/transport"
 	"log"
 )
 
@@ -17,7 +19,10 @@ type Contx struct {
 	Closers       []func()
 	log           *log.Logger
 	cfg           *domain.PlayerConfig
-	redis         *redis.Client
+	redis         redis.UniversalClient
+	transport     transport.Transport
+	registrar     app.Registrar
+	strategy      strategy.Strategy
 	playerService *app.Player
 }
 
@@ -2,11 +2,17 @@ package app
 
 import (
 	"fmt"
-	"github.com/go-redis/redis/v8"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/reactivejson/cowboys/internal/app"
+	"github.com/reactivejson/cowboys/internal/clock"
+	"github.com/reactivejson/cowboys/internal/codec"
 	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/grpcapi"
+	"github.com/reactivejson/cowboys/internal/strategy"
+	"github.com/reactivejson/cowboys/internal/transport"
 	"log"
+	"os"
+	"time"
 )
 
 /**
@@ -28,7 +34,7 @@ func SetupEnvConfig() *domain.PlayerConfig {
 
 	cfg := &domain.PlayerConfig{}
 	if err := envconfig.Process("", cfg); err != nil {
-		fmt.Errorf("could not parse config: %w", err)
+		log.Fatalf("could not parse config: %v", err)
 	}
 	return cfg
 }
@@ -36,10 +42,115 @@ func SetupEnvConfig() *domain.PlayerConfig {
 func setupRedis() setupFn {
 	return func(c *Contx) (err error) {
 		if c.redis == nil {
-			options := &redis.Options{
-				Addr: c.cfg.RedisAddr,
+			c.redis, err = transport.NewRedisClient(&c.cfg.RedisConfig)
+			if err != nil {
+				return fmt.Errorf("setup redis: %w", err)
+			}
+
+			c.Closers = append(c.Closers, func() {
+				if err := c.redis.Close(); err != nil {
+					c.log.Printf("close redis client: %v", err)
+				}
+			})
+		}
+		return nil
+	}
+}
+
+func setupTransport() setupFn {
+	return func(c *Contx) (err error) {
+		if c.transport == nil {
+			if err := c.cfg.ValidateKeys(); err != nil {
+				return fmt.Errorf("setup transport: %w", err)
+			}
+
+			if _, err := codec.New(c.cfg.Codec); err != nil {
+				return fmt.Errorf("setup transport: %w", err)
+			}
+
+			if err := c.cfg.OutboxConfig.Validate(); err != nil {
+				return fmt.Errorf("setup transport: %w", err)
+			}
+
+			if err := c.cfg.SubscriptionConfig.Validate(); err != nil {
+				return fmt.Errorf("setup transport: %w", err)
+			}
+
+			if c.cfg.ResubscribeTimeout < 0 || c.cfg.HeartbeatTimeout < 0 {
+				return fmt.Errorf("setup transport: heartbeat and resubscription timeouts can not be negative")
+			}
+
+			c.transport = transport.NewRedis(c.redis)
+			c.registrar = app.NewHTTPRegistrar(c.cfg.MasterAddr)
+
+			// Over gRPC, the master both registers the cowboy and relays its events.
+			if c.cfg.GRPCAddr != "" {
+				client, err := grpcapi.Dial(c.cfg.GRPCAddr, c.log)
+				if err != nil {
+					return fmt.Errorf("setup gRPC transport: %w", err)
+				}
+
+				c.Closers = append(c.Closers, func() {
+					if err := client.Close(); err != nil {
+						c.log.Printf("close gRPC connection: %v", err)
+					}
+				})
+				c.transport = client
+				c.registrar = client
+				// The gRPC client wraps the JSON events of the player in its own protobuf envelope.
+				c.cfg.Codec = codec.NameJSON
+			}
+
+			if c.cfg.Chaos != "" {
+				chaos, err := transport.ParseChaosConfig(c.cfg.Chaos)
+				if err != nil {
+					return fmt.Errorf("setup chaos transport: %w", err)
+				}
+
+				c.log.Printf("injecting faults into the transport: %s", c.cfg.Chaos)
+				c.transport = transport.NewChaos(c.transport, chaos, c.log)
+			}
+		}
+		return nil
+	}
+}
+
+func setupStrategy() setupFn {
+	return func(c *Contx) (err error) {
+		if c.strategy == nil {
+			builtin, err := strategy.New(c.cfg.Strategy)
+			if err != nil {
+				return fmt.Errorf("setup strategy: %w", err)
+			}
+
+			// The player decides while receiving the events of the master, a slower decision loses it. The webhook
+			// only decides once the interactive choice timed out, so their timeouts add up.
+			var decision time.Duration
+
+			c.strategy = builtin
+			if c.cfg.StrategyURL != "" {
+				if c.cfg.StrategyTimeout >= c.cfg.Heartbeat() {
+					return fmt.Errorf("setup strategy: the strategy timeout %s must be shorter than the heartbeat timeout %s",
+						c.cfg.StrategyTimeout, c.cfg.Heartbeat())
+				}
+
+				decision += c.cfg.StrategyTimeout
+				c.strategy = strategy.NewWebhook(c.cfg.StrategyURL, c.cfg.StrategyTimeout, builtin, c.log)
+			}
+
+			if c.cfg.Interactive {
+				if c.cfg.ChoiceTimeout >= c.cfg.Heartbeat() {
+					return fmt.Errorf("setup strategy: the choice timeout %s must be shorter than the heartbeat timeout %s",
+						c.cfg.ChoiceTimeout, c.cfg.Heartbeat())
+				}
+
+				if decision += c.cfg.ChoiceTimeout; decision >= c.cfg.Heartbeat() {
+					return fmt.Errorf("setup strategy: the choice timeout %s and the strategy timeout %s must add up to less than the heartbeat timeout %s",
+						c.cfg.ChoiceTimeout, c.cfg.StrategyTimeout, c.cfg.Heartbeat())
+				}
+
+				c.strategy = strategy.NewInteractive(os.Stdin, os.Stdout, c.cfg.ChoiceTimeout, c.strategy)
 			}
-			c.redis = redis.NewClient(options)
 		}
 		return nil
 	}
@@ -48,8 +159,10 @@ func setupRedis() setupFn {
 func setupPlayerService() setupFn {
 	return func(c *Contx) (err error) {
 		if c.playerService == nil {
-			c.playerService = app.NewPlayer(c.cfg, c.redis, c.log)
-			c.playerService.Run()
+			c.playerService = app.NewPlayer(c.cfg, c.strategy, c.transport, c.registrar, clock.Real{}, c.log)
+			if err := c.playerService.Run(); err != nil {
+				return fmt.Errorf("play: %w", err)
+			}
 		}
 		return nil
 	}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"github.com/reactivejson/cowboys/cmd/player/app"
+	player "github.com/reactivejson/cowboys/internal/app"
 	"log"
 	"os"
 	"os/signal"
@@ -16,9 +18,15 @@ import (
  */
 
 func main() {
+	interactive := flag.Bool("interactive", false, "pick the targets from the terminal")
+	flag.Parse()
+
 	ctx, cancelCtxFn := context.WithCancel(context.Background())
 
 	cfg := app.SetupEnvConfig()
+	if *interactive {
+		cfg.Interactive = true
+	}
 
 	fmt.Printf("Running player %s\n", cfg.Name)
 
@@ -43,7 +51,9 @@ func waitForSignal(ctx context.Context, cancel context.CancelFunc) {
 
 func logExitMsg(err error) {
 	if err != nil {
-		log.Fatalf("Service failed to setup: %s", err)
+		log.Printf("Service failed: %s", err)
+		// The exit code tells a supervisor whether restarting the player can help.
+		os.Exit(player.ExitCode(err))
 	}
 
 	log.Print("Service exited successfully")
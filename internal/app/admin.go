@@ -0,0 +1,137 @@
+package app
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+)
+
+const adminPath = "/admin/"
+
+type adminRequest struct {
+	ID       string `json:"id"`
+	Health   *int   `json:"health"`
+	Damage   *int   `json:"damage"`
+	Interval string `json:"interval"`
+	Winner   string `json:"winner"`
+
+	Effect *domain.Effect `json:"effect"`
+}
+
+// Administer applies an operator action to the game and to the tick loop.
+func (m *Master) Administer(action *domain.AdminAction) error {
+	if err := m.state.Administer(action); err != nil {
+		return err
+	}
+
+	switch action.Command {
+	case domain.AdminPause:
+		atomic.StoreInt32(&m.paused, 1)
+	case domain.AdminResume:
+		atomic.StoreInt32(&m.paused, 0)
+	case domain.AdminTick:
+		m.ticker.Reset(action.Interval)
+	}
+
+	m.logger.Printf("admin %s %s", action.Command, action.Target)
+
+	return nil
+}
+
+// handleAdmin serves POST /admin/<command>, authenticated with the admin token as a bearer token.
+func (m *Master) handleAdmin(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(m.cfg.AdminToken)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var request adminRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+	}
+
+	action, err := newAdminAction(domain.AdminCommand(strings.TrimPrefix(r.URL.Path, adminPath)), &request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := m.Administer(action); err != nil {
+		switch {
+		case errors.Is(err, game.ErrUnknownPlayer):
+			http.Error(w, "unknown cowboy", http.StatusNotFound)
+		case errors.Is(err, game.ErrInvalidPayload):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, game.ErrGameFinished):
+			http.Error(w, "conflict", http.StatusConflict)
+		default:
+			m.logger.Printf("admin %s: %v", action.Command, err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(m.state.Snapshot()); err != nil {
+		m.logger.Printf("encode admin response: %v", err)
+	}
+}
+
+func newAdminAction(command domain.AdminCommand, request *adminRequest) (*domain.AdminAction, error) {
+	action := &domain.AdminAction{
+		Command: command,
+		Target:  request.ID,
+		Health:  request.Health,
+		Damage:  request.Damage,
+		Effect:  request.Effect,
+	}
+
+	switch command {
+	case domain.AdminTick:
+		interval, err := time.ParseDuration(request.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tick interval %q", request.Interval)
+		}
+
+		action.Interval = interval
+	case domain.AdminEnd:
+		action.Target = request.Winner
+	}
+
+	return action, nil
+}
+
+// keepAlive sends a heartbeat when no event went out lately, so that the players do not give up
+// on the master while the game is paused or ticks slowly.
+func (m *Master) keepAlive() {
+	if m.clock.Now().Sub(m.lastPublish) < keepAliveInterval/2 {
+		return
+	}
+
+	event, err := game.NewEvent(game.Heartbeat, nil)
+	if err != nil {
+		m.logger.Printf("create heartbeat event: %v", err)
+		return
+	}
+
+	if err := m.publish(event); err != nil {
+		m.logger.Printf("publish heartbeat event: %v", err)
+	}
+}
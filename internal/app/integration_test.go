@@ -0,0 +1,305 @@
+//go:build integration
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/clock"
+	"github.com/reactivejson/cowboys/internal/codec"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/strategy"
+	"github.com/reactivejson/cowboys/internal/transport"
+)
+
+const integrationTimeout = time.Minute
+
+// arena runs a real master and real players in one process, over an in-memory bus.
+type arena struct {
+	t       *testing.T
+	bus     *transport.Memory
+	master  *Master
+	players []*Player
+
+	lock     sync.Mutex
+	recorded []*game.Event
+	rounds   []*domain.Round
+}
+
+func newArena(t *testing.T, cfg *domain.MasterConfig, roster ...*domain.PlayerConfig) *arena {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("find a free port: %v", err)
+	}
+
+	addr := listener.Addr().String()
+	if err := listener.Close(); err != nil {
+		t.Fatalf("release the free port: %v", err)
+	}
+
+	cfg.Port = addr
+	cfg.Players = len(roster)
+	cfg.Namespace, cfg.GameID = "test", "arena"
+
+	a := &arena{t: t, bus: transport.NewMemory()}
+
+	state := game.NewGame(cfg)
+	state.Observe(func(event *game.Event) {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+
+		a.recorded = append(a.recorded, event)
+	})
+
+	a.master = NewMaster(cfg, state, clock.Real{}, logger(t, "master"), a.bus)
+
+	for _, playerCfg := range roster {
+		playerCfg.MasterAddr = "http://" + addr
+		playerCfg.Namespace, playerCfg.GameID = cfg.Namespace, cfg.GameID
+		a.players = append(a.players, NewPlayer(playerCfg, strategy.Weakest{}, a.bus, NewHTTPRegistrar(playerCfg.MasterAddr), clock.Real{}, logger(t, playerCfg.Name)))
+	}
+
+	return a
+}
+
+func logger(t *testing.T, name string) *log.Logger {
+	if testing.Verbose() {
+		return log.New(testWriter{t}, name+": ", log.Lmicroseconds)
+	}
+
+	return log.New(io.Discard, "", 0)
+}
+
+type testWriter struct {
+	t *testing.T
+}
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Log(string(p))
+	return len(p), nil
+}
+
+// run plays the game until the master and every player shut down, and the published rounds are recorded.
+func (a *arena) run() {
+	a.t.Helper()
+
+	rounds := a.bus.Subscribe(a.master.ctx, a.master.cfg.Key(masterTopic))
+	watched := make(chan struct{})
+
+	go a.watch(rounds, watched)
+
+	defer func() {
+		rounds.Close()
+		<-watched
+	}()
+
+	var wg sync.WaitGroup
+
+	wg.Add(1 + len(a.players))
+
+	go func() {
+		defer wg.Done()
+		a.master.Run()
+	}()
+
+	for _, player := range a.players {
+		go func(player *Player) {
+			defer wg.Done()
+			player.Run()
+		}(player)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(integrationTimeout):
+		a.master.cancel()
+		for _, player := range a.players {
+			player.cancel()
+		}
+
+		a.t.Fatalf("game did not finish within %s", integrationTimeout)
+	}
+}
+
+// watch records the rounds published to the players, applying the deltas like a player does, and closes done once
+// the subscription is drained.
+func (a *arena) watch(subscription transport.Subscription, done chan<- struct{}) {
+	defer close(done)
+
+	var last *domain.Round
+	for msg := range subscription.Channel() {
+		event, err := codec.Unmarshal(msg.Payload)
+		if err != nil || event.Type != game.EventRound {
+			continue
+		}
+
+		var update domain.Round
+		if err := json.Unmarshal(event.Data, &update); err != nil {
+			continue
+		}
+
+		round, err := last.Apply(&update)
+		if err != nil {
+			a.t.Errorf("apply round %d: %v", update.Number, err)
+			continue
+		}
+
+		last = round
+
+		a.lock.Lock()
+		a.rounds = append(a.rounds, round)
+		a.lock.Unlock()
+	}
+}
+
+// events returns the recorded events of the given type.
+func (a *arena) events(eventType game.EventType) []*game.Event {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var events []*game.Event
+	for _, event := range a.recorded {
+		if event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// published returns the recorded rounds.
+func (a *arena) published() []*domain.Round {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return append([]*domain.Round(nil), a.rounds...)
+}
+
+func (a *arena) result() *domain.Result {
+	a.t.Helper()
+
+	gameOver := a.events(game.EventGameOver)
+	if len(gameOver) != 1 {
+		a.t.Fatalf("expected one game over event, got %d", len(gameOver))
+	}
+
+	var result domain.Result
+	if err := json.Unmarshal(gameOver[0].Data, &result); err != nil {
+		a.t.Fatalf("unmarshal result: %v", err)
+	}
+
+	return &result
+}
+
+func cowboy(name string, health, damage int) *domain.PlayerConfig {
+	return &domain.PlayerConfig{
+		Name:   name,
+		Health: health,
+		Damage: damage,
+	}
+}
+
+func TestIntegrationGame(t *testing.T) {
+	a := newArena(t,
+		&domain.MasterConfig{ReadyCheck: true, SnapshotEvery: 3, RedisConfig: domain.RedisConfig{Codec: codec.NameProtobuf}},
+		cowboy("p1", 10, 3),
+		cowboy("p2", 5, 4),
+		cowboy("p3", 10, 1),
+		cowboy("p4", 7, 2),
+	)
+
+	a.run()
+
+	if registrations := a.events(game.Registration); len(registrations) != 4 {
+		t.Fatalf("expected 4 registrations, got %d", len(registrations))
+	}
+
+	if kills := a.events(game.EventKill); len(kills) != 3 {
+		t.Fatalf("expected 3 deaths, got %d", len(kills))
+	}
+
+	rounds := a.published()
+	if len(rounds) < 2 {
+		t.Fatalf("expected several rounds, got %d", len(rounds))
+	}
+
+	for i, round := range rounds {
+		if round.Number != i+1 {
+			t.Fatalf("expected round %d, got round %d", i+1, round.Number)
+		}
+	}
+
+	result := a.result()
+	if result.Winner == nil || result.Draw || len(result.Standings) != 4 {
+		t.Fatalf("expected a winner and 4 cowboys in the standings, got %+v", result)
+	}
+
+	last := rounds[len(rounds)-1]
+	if _, ok := last.Players[result.Winner.ID]; !ok || len(last.Players) != 1 {
+		t.Fatalf("expected the last round to announce the winner %s, got %+v", result.Winner.Name, last.Players)
+	}
+
+	for _, player := range a.players {
+		if player.ctx.Err() == nil {
+			t.Fatalf("expected player %s to be shut down", player.cfg.Name)
+		}
+	}
+}
+
+func TestIntegrationLockstepDraw(t *testing.T) {
+	a := newArena(t,
+		&domain.MasterConfig{ReadyCheck: true, Lockstep: true},
+		cowboy("p1", 1, 1),
+		cowboy("p2", 1, 1),
+	)
+
+	a.run()
+
+	result := a.result()
+	if !result.Draw || result.Winner != nil {
+		t.Fatalf("expected a draw, got %+v", result)
+	}
+
+	if kills := a.events(game.EventKill); len(kills) != 2 {
+		t.Fatalf("expected both cowboys to die, got %d deaths", len(kills))
+	}
+}
+
+func TestIntegrationStallAbort(t *testing.T) {
+	a := newArena(t,
+		&domain.MasterConfig{StallRounds: 1, StallPolicy: domain.StallAbort},
+		cowboy("p1", 10, 1),
+		cowboy("p2", 10, 1),
+	)
+
+	// Every shot is lost on its way to the master, so nobody ever takes damage.
+	for i, player := range a.players {
+		chaos := transport.NewChaos(a.bus, &transport.ChaosConfig{
+			Topics: map[string]transport.Faults{playerTopic: {Drop: 1}},
+		}, logger(t, fmt.Sprintf("chaos-%d", i)))
+		player.transport = chaos
+	}
+
+	a.run()
+
+	result := a.result()
+	if result.Reason == "" || result.Winner != nil {
+		t.Fatalf("expected the stalled game to be aborted, got %+v", result)
+	}
+}
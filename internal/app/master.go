@@ -1,65 +1,127 @@
 package app
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/reactivejson/cowboys/internal/dashboard"
 	"github.com/reactivejson/cowboys/internal/domain"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"time"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	"github.com/reactivejson/cowboys/internal/clock"
+	"github.com/reactivejson/cowboys/internal/codec"
 	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/transport"
 )
 
 const (
 	masterTopic  = "master_events"
 	registerPath = "/join"
+
+	metricsPath = "/metrics"
+	healthPath  = "/healthz"
+
+	// keepAliveInterval is how often the master makes sure the players heard from it.
+	keepAliveInterval = time.Second
+	// flushTimeout bounds how long a stopping process waits for its queued events to be published.
+	flushTimeout = 5 * time.Second
 )
 
 type registrationRequest struct {
-	Name   string `json:"name"`
-	Health int    `json:"health"`
-	Damage int    `json:"damage"`
+	Name   string         `json:"name"`
+	Health int            `json:"health"`
+	Damage int            `json:"damage"`
+	Speed  float64        `json:"speed,omitempty"`
+	Weapon *domain.Weapon `json:"weapon,omitempty"`
 }
 
 type Master struct {
-	cfg           *domain.MasterConfig
-	ctx           context.Context
-	cancel        context.CancelFunc
-	state         *game.Game
-	logger        *log.Logger
-	redisClient   *redis.Client
-	lastRoundData json.RawMessage
+	cfg       *domain.MasterConfig
+	ctx       context.Context
+	cancel    context.CancelFunc
+	state     *game.Game
+	logger    *log.Logger
+	transport transport.Transport
+	outbox    *transport.Outbox
+	wire      codec.Codec
+	hub       *dashboard.Hub
+	// broadcast replays the events published to the players to the ones playing over gRPC.
+	broadcast *dashboard.Hub
+
+	clock       clock.Clock
+	ticker      clock.Ticker
+	paused      int32
+	lastPublish time.Time
+	rounds      *roundEncoder
+	// subscription receives the competitor events, set by Run.
+	subscription *transport.Supervisor
 }
 
-func NewMaster(cfg *domain.MasterConfig, state *game.Game, logger *log.Logger, redisClient *redis.Client) *Master {
+// NewMaster creates the master of the game, which reads the time from the clock and makes the game read it too.
+func NewMaster(cfg *domain.MasterConfig, state *game.Game, clk clock.Clock, logger *log.Logger, bus transport.Transport) *Master {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 
+	hub := dashboard.NewHub()
+	state.Observe(hub.Publish)
+	state.UseClock(clk)
+
+	tick := cfg.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+
+	wire, err := codec.New(cfg.Codec)
+	if err != nil {
+		logger.Printf("%v, publishing JSON", err)
+		wire = codec.JSON{}
+	}
+
+	outbox := transport.NewOutbox(bus, &cfg.OutboxConfig, logger)
+
 	return &Master{
-		ctx:         ctx,
-		cancel:      cancel,
-		cfg:         cfg,
-		state:       state,
-		logger:      logger,
-		redisClient: redisClient,
+		ctx:       ctx,
+		cancel:    cancel,
+		cfg:       cfg,
+		state:     state,
+		logger:    logger,
+		transport: outbox,
+		outbox:    outbox,
+		wire:      wire,
+		hub:       hub,
+		broadcast: dashboard.NewHub(),
+		clock:     clk,
+		ticker:    clk.NewTicker(tick),
+		rounds:    &roundEncoder{every: cfg.SnapshotEvery},
 	}
 }
 
 func (m *Master) Run() {
+	m.subscription = transport.Supervise(m.transport, m.cfg.Key(playerTopic), &m.cfg.SubscriptionConfig, m.logger)
+
 	mux := http.NewServeMux()
 	mux.HandleFunc(registerPath, m.handleRegistration)
+	mux.HandleFunc(metricsPath, m.handleMetrics)
+	mux.HandleFunc(healthPath, m.handleHealth)
+	if m.cfg.AdminToken != "" {
+		mux.HandleFunc(adminPath, m.handleAdmin)
+	}
+	mux.Handle("/", dashboard.Handler(m.hub, m.logger))
 
 	server := &http.Server{
 		Addr:    m.cfg.Port,
 		Handler: mux,
 	}
 
-	ticker := time.Tick(time.Second)
+	keepAlive := m.clock.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	defer m.ticker.Stop()
 
 	go func() {
 		if err := server.ListenAndServe(); err != nil {
@@ -67,14 +129,18 @@ func (m *Master) Run() {
 		}
 	}()
 
+	stopGRPC := m.serveGRPC()
+
 	go func() {
-		subscription := m.redisClient.Subscribe(m.ctx, playerTopic)
 		for {
 			select {
-			case msg := <-subscription.Channel():
+			case msg := <-m.subscription.Channel():
 				m.handleMessage(msg)
+			// The events the players published meanwhile are lost, and they may have missed rounds too.
+			case <-m.subscription.Gaps():
+				m.rounds.snapshot()
 			case <-m.ctx.Done():
-				if err := subscription.Close(); err != nil {
+				if err := m.subscription.Close(); err != nil {
 					m.logger.Printf("close competitor events channel: %v", err)
 				}
 
@@ -85,107 +151,259 @@ func (m *Master) Run() {
 
 	for {
 		select {
-		case <-ticker:
-			m.beat()
+		case <-m.ticker.C():
+			if atomic.LoadInt32(&m.paused) == 0 {
+				m.beat()
+			}
+		case <-keepAlive.C():
+			m.keepAlive()
 		case <-m.ctx.Done():
+			// Viewers hold streaming connections open, disconnect them before shutting the servers down.
+			m.hub.Close()
+			m.broadcast.Close()
+			stopGRPC()
+
 			shutdownCtx, cancel := context.WithTimeout(context.TODO(), time.Minute)
 			if err := server.Shutdown(shutdownCtx); err != nil {
 				m.logger.Printf("master HTTP server shutdown: %v", err)
 			}
 
 			cancel()
+			flush(m.outbox, m.logger)
 			return
 		}
 	}
 }
 
-func (m *Master) handleMessage(msg *redis.Message) {
-	var event game.Event
-	if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
-		m.logger.Printf("unmarshal competitor event: %v", err)
-		m.cancel()
+// handleMetrics serves GET /metrics, the counters of the events the master published and received.
+func (m *Master) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	err := m.state.HandleEvent(&event)
-	if err != nil && err != game.ErrInvalidPayload {
-		m.logger.Printf("handle competitor event: %v", err)
-		m.cancel()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"outbox":       m.outbox.Stats(),
+		"subscription": m.subscription.Stats(),
+	}); err != nil {
+		m.logger.Printf("encode metrics: %v", err)
+	}
+}
+
+// handleHealth serves GET /healthz, which fails while the master is not subscribed to the competitor events.
+func (m *Master) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if down := m.subscription.Down(); down > 0 {
+		http.Error(w, fmt.Sprintf("subscription down for %s", down.Round(time.Millisecond)), http.StatusServiceUnavailable)
 		return
 	}
 
-	if err != nil {
-		m.logger.Printf("competitor event: %v", err)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// flush publishes the events still queued in the outbox of a stopping process, and logs its counters.
+func flush(outbox *transport.Outbox, logger *log.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := outbox.Close(ctx); err != nil {
+		logger.Printf("flush outbox: %v", err)
 	}
+
+	stats := outbox.Stats()
+	logger.Printf("outbox: %d sent, %d retries, %d dropped, %d failed, %d lost", stats.Sent, stats.Retries, stats.Dropped, stats.Failed, stats.Queued)
 }
 
-func (m *Master) beat() {
-	event, err := m.state.EmitEvent()
+// handleMessage applies a competitor event to the game. A single malformed or unexpected event is
+// dropped, it must not abort the game of everybody else.
+func (m *Master) handleMessage(msg *transport.Message) {
+	event, err := codec.Unmarshal(msg.Payload)
 	if err != nil {
-		m.logger.Printf("emit event: %v", err)
-		m.cancel()
+		m.logger.Printf("unmarshal competitor event, dropping it: %v", err)
 		return
 	}
 
-	if event.Type == game.EventRound {
-		defer func() { m.lastRoundData = event.Data }()
+	if err := m.checkGame(event); err != nil {
+		m.logger.Printf("dropping %s event: %v", event.Type, err)
+		return
 	}
 
-	if bytes.Equal(event.Data, m.lastRoundData) {
-		m.logger.Printf("state did not change, not enough competitors")
-		m.cancel()
-		return
+	if err := m.apply(event); err != nil {
+		m.logger.Printf("handle competitor %s event, dropping it: %v", event.Type, err)
+	}
+}
+
+// checkGame rejects the event of another game. Games sharing a Redis have their own topics, such an event
+// comes from a misconfigured competitor.
+func (m *Master) checkGame(event *game.Event) error {
+	if event.Game != "" && event.Game != m.cfg.GameID {
+		return fmt.Errorf("%w %q", game.ErrForeignGame, event.Game)
 	}
 
-	payload, err := json.Marshal(event)
+	return nil
+}
+
+// apply applies a competitor event to the game. The messages and the alliance steps the game accepted
+// are relayed to the players, the master being the only route between them.
+func (m *Master) apply(event *game.Event) error {
+	if err := m.state.HandleEvent(event); err != nil {
+		return err
+	}
+
+	switch event.Type {
+	case game.EventMessage, game.EventAlliance:
+		if err := m.send(event); err != nil {
+			return fmt.Errorf("relay %s event: %w", event.Type, err)
+		}
+	case game.EventResync:
+		var resync domain.Resync
+		if err := json.Unmarshal(event.Data, &resync); err != nil {
+			return fmt.Errorf("%w: %v", game.ErrInvalidPayload, err)
+		}
+
+		m.logger.Printf("cowboy %s missed the rounds after %d, the next round is a snapshot", resync.ID, resync.Last)
+		m.rounds.snapshot()
+	}
+
+	return nil
+}
+
+func (m *Master) beat() {
+	event, err := m.state.EmitEvent()
 	if err != nil {
-		m.logger.Printf("marshal event: %v", err)
+		m.logger.Printf("emit event: %v", err)
+		m.announceResult()
 		m.cancel()
 		return
 	}
 
-	if err := m.redisClient.Publish(m.ctx, masterTopic, payload).Err(); err != nil {
+	m.hub.Publish(event)
+
+	if err := m.publish(event); err != nil {
 		m.logger.Printf("publish event: %v", err)
 		m.cancel()
 		return
 	}
 }
 
-func (m *Master) handleRegistration(w http.ResponseWriter, r *http.Request) {
-	var request registrationRequest
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		m.logger.Printf("decode request body: %v", err)
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+// announceResult tells the players still listening how the game ended, for instance when it was aborted,
+// and saves the result to the result file if any.
+func (m *Master) announceResult() {
+	result := m.state.Result()
+	if result == nil {
 		return
 	}
 
-	if request.Name == "" || request.Health == 0 || request.Damage == 0 {
-		http.Error(w, "bad request", http.StatusBadRequest)
+	if m.cfg.ResultFile != "" {
+		if err := writeResult(m.cfg.ResultFile, result); err != nil {
+			m.logger.Printf("write result: %v", err)
+		}
+	}
+
+	event, err := game.NewEvent(game.EventGameOver, result)
+	if err != nil {
+		m.logger.Printf("create game over event: %v", err)
 		return
 	}
 
+	if err := m.publish(event); err != nil {
+		m.logger.Printf("publish game over event: %v", err)
+	}
+}
+
+// writeResult saves the result as JSON, for the launcher of the master to report it.
+func writeResult(path string, result *domain.Result) error {
+	payload, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal result: %w", err)
+	}
+
+	return os.WriteFile(path, payload, 0o644)
+}
+
+// publish sends an event of the game loop to the players, which keeps them alive.
+func (m *Master) publish(event *game.Event) error {
+	m.lastPublish = m.clock.Now()
+
+	return m.send(event)
+}
+
+// send publishes an event to the players, over Redis and gRPC. It is safe to call from any goroutine, except
+// for rounds: only the game loop sends them, delta encoded over Redis.
+func (m *Master) send(event *game.Event) error {
+	m.broadcast.Publish(event)
+
+	stamped := *event
+	stamped.Game = m.cfg.GameID
+
+	if event.Type == game.EventRound {
+		data, err := m.rounds.encode(event.Data)
+		if err != nil {
+			return fmt.Errorf("encode round: %w", err)
+		}
+
+		stamped.Data = data
+	}
+
+	payload, err := m.wire.Marshal(&stamped)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", event.Type, err)
+	}
+
+	return m.transport.Publish(m.ctx, m.cfg.Key(masterTopic), payload)
+}
+
+// Register adds a cowboy to the game. The weapon, if any, replaces the damage.
+func (m *Master) Register(name string, health, damage int, speed float64, weapon *domain.Weapon) (*domain.Player, error) {
+	if weapon != nil {
+		damage = weapon.Damage
+	}
+
+	if name == "" || health == 0 || damage == 0 {
+		return nil, game.ErrInvalidPlayerRegistration
+	}
+
 	player := domain.Player{
 		ID:     uuid.NewString(),
-		Name:   request.Name,
-		Health: request.Health,
-		Damage: request.Damage,
+		Name:   name,
+		Health: health,
+		Damage: damage,
+		Speed:  speed,
+		Weapon: weapon,
 	}
 
 	event, err := game.NewEvent(game.Registration, &player)
 	if err != nil {
-		m.logger.Printf("create registration event: %v", err)
+		return nil, fmt.Errorf("create registration event: %w", err)
+	}
+
+	if err := m.state.HandleEvent(event); err != nil {
+		return nil, err
+	}
+
+	return &player, nil
+}
+
+func (m *Master) handleRegistration(w http.ResponseWriter, r *http.Request) {
+	var request registrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		m.logger.Printf("decode request body: %v", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	if err = m.state.HandleEvent(event); err != nil {
-		if err == game.ErrGameFinished || err == game.ErrGameAlreadyStarted {
+	player, err := m.Register(request.Name, request.Health, request.Damage, request.Speed, request.Weapon)
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrInvalidPlayerRegistration):
+			http.Error(w, "bad request", http.StatusBadRequest)
+		case errors.Is(err, game.ErrGameFinished), errors.Is(err, game.ErrGameAlreadyStarted):
 			http.Error(w, "conflict", http.StatusConflict)
-			return
+		default:
+			m.logger.Printf("create registration event: %v", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
 		}
 
-		m.logger.Printf("create registration event: %v", err)
-		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"net"
+
+	cowboysv1 "github.com/reactivejson/cowboys/api/cowboys/v1"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/grpcapi"
+	"google.golang.org/grpc"
+)
+
+// serveGRPC starts the gRPC API next to the HTTP one, when a port is configured.
+// It returns the function stopping it.
+func (m *Master) serveGRPC() func() {
+	if m.cfg.GRPCPort == "" {
+		return func() {}
+	}
+
+	listener, err := net.Listen("tcp", m.cfg.GRPCPort)
+	if err != nil {
+		m.logger.Printf("master gRPC server listen: %v", err)
+		return func() {}
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpcapi.Authorize(m.cfg.AdminToken)))
+	cowboysv1.RegisterArenaServer(server, grpcapi.NewServer(m, m.logger))
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			m.logger.Printf("master gRPC server serve: %v", err)
+		}
+	}()
+
+	return server.GracefulStop
+}
+
+// State returns a snapshot of the game.
+func (m *Master) State() *domain.State {
+	return m.state.Snapshot()
+}
+
+// Subscribe streams the events broadcast to the players. With journal, it streams the events the
+// viewers get instead, which add the registrations, the kills and the result.
+func (m *Master) Subscribe(journal bool) (<-chan *game.Event, func()) {
+	if journal {
+		return m.hub.Subscribe()
+	}
+
+	return m.broadcast.Subscribe()
+}
+
+// Submit applies a player event received over the API, unless it belongs to another game.
+func (m *Master) Submit(event *game.Event) error {
+	if err := m.checkGame(event); err != nil {
+		return err
+	}
+
+	return m.apply(event)
+}
+
+// Abort ends the game without a winner. The players are told on the next tick.
+func (m *Master) Abort(reason string) error {
+	return m.state.Abort(reason)
+}
@@ -0,0 +1,324 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/reactivejson/cowboys/internal/clock"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/strategy"
+	"github.com/reactivejson/cowboys/internal/transport"
+)
+
+func TestMasterSurvivesMalformedEvents(t *testing.T) {
+	cfg := &domain.MasterConfig{Players: 2}
+	master := NewMaster(cfg, game.NewGame(cfg), clock.Real{}, log.New(io.Discard, "", 0), transport.NewMemory())
+	defer master.cancel()
+
+	for _, payload := range []string{`{"type":"sh`, `{"type":"shot","data":"bang"}`, `{"type":"ready","data":{}}`} {
+		master.handleMessage(&transport.Message{Payload: []byte(payload)})
+	}
+
+	if master.ctx.Err() != nil {
+		t.Fatalf("expected the master to keep running after malformed events")
+	}
+}
+
+func TestMasterDropsForeignEvents(t *testing.T) {
+	cfg := &domain.MasterConfig{Players: 2, RedisConfig: domain.RedisConfig{Namespace: "cowboys", GameID: "duel"}}
+	bus := transport.NewMemory()
+	master := NewMaster(cfg, game.NewGame(cfg), clock.Real{}, log.New(io.Discard, "", 0), bus)
+	defer master.cancel()
+
+	var ids []string
+	for _, name := range []string{"bill", "jesse"} {
+		player, err := master.Register(name, 10, 1, 0, nil)
+		if err != nil {
+			t.Fatalf("unexpected registration err: %v", err)
+		}
+
+		ids = append(ids, player.ID)
+	}
+
+	relayed := bus.Subscribe(master.ctx, "cowboys:duel:master_events")
+	defer relayed.Close()
+
+	for _, gameID := range []string{"other", "duel"} {
+		event, _ := game.NewEvent(game.EventMessage, &domain.Message{From: ids[0], To: ids[1], Text: "from " + gameID})
+		event.Game = gameID
+		payload, _ := json.Marshal(event)
+
+		master.handleMessage(&transport.Message{Payload: payload})
+	}
+
+	select {
+	case msg := <-relayed.Channel():
+		var event game.Event
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			t.Fatalf("unmarshal relayed event: %v", err)
+		}
+
+		var message domain.Message
+		_ = json.Unmarshal(event.Data, &message)
+		if event.Game != "duel" || message.Text != "from duel" {
+			t.Fatalf("expected only the message of the game to be relayed, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no message relayed")
+	}
+
+	select {
+	case msg := <-relayed.Channel():
+		t.Fatalf("unexpected relayed event %s", msg.Payload)
+	default:
+	}
+
+	// The events submitted over gRPC are checked the same way.
+	event, _ := game.NewEvent(game.EventMessage, &domain.Message{From: ids[0], To: ids[1], Text: "over gRPC"})
+	event.Game = "other"
+	if err := master.Submit(event); !errors.Is(err, game.ErrForeignGame) {
+		t.Fatalf("expected ErrForeignGame, got: %v", err)
+	}
+}
+
+func TestMasterDeltaRounds(t *testing.T) {
+	cfg := &domain.MasterConfig{Players: 2, SnapshotEvery: 3}
+	bus := transport.NewMemory()
+	master := NewMaster(cfg, game.NewGame(cfg), clock.Real{}, log.New(io.Discard, "", 0), bus)
+	defer master.cancel()
+
+	published := bus.Subscribe(master.ctx, cfg.Key(masterTopic))
+	defer published.Close()
+
+	bill := func(health int) *domain.Player {
+		return &domain.Player{ID: "bill", Name: "Bill", Health: health, Damage: 1}
+	}
+
+	jesse := &domain.Player{ID: "jesse", Name: "Jesse", Health: 5, Damage: 2}
+
+	receive := func(round *domain.Round) *domain.Round {
+		t.Helper()
+
+		event, _ := game.NewEvent(game.EventRound, round)
+		if err := master.publish(event); err != nil {
+			t.Fatalf("unexpected publish err: %v", err)
+		}
+
+		select {
+		case msg := <-published.Channel():
+			var event game.Event
+			var round domain.Round
+			if err := json.Unmarshal(msg.Payload, &event); err != nil || json.Unmarshal(event.Data, &round) != nil {
+				t.Fatalf("unmarshal published round: %s", msg.Payload)
+			}
+
+			return &round
+		case <-time.After(time.Second):
+			t.Fatal("no round published")
+			return nil
+		}
+	}
+
+	first := receive(&domain.Round{Number: 1, Players: map[string]*domain.Player{"bill": bill(10), "jesse": jesse}})
+	if first.Delta || len(first.Players) != 2 {
+		t.Fatalf("expected the first round to be a snapshot, got %+v", first)
+	}
+
+	second := receive(&domain.Round{Number: 2, Players: map[string]*domain.Player{"bill": bill(8), "jesse": jesse}})
+	if !second.Delta || len(second.Players) != 1 || second.Players["bill"].Health != 8 {
+		t.Fatalf("expected a delta carrying bill only, got %+v", second)
+	}
+
+	if third := receive(&domain.Round{Number: 3, Players: map[string]*domain.Player{"bill": bill(8), "jesse": jesse}}); third.Delta {
+		t.Fatalf("expected a snapshot every 3 rounds, got %+v", third)
+	}
+
+	fourth := receive(&domain.Round{Number: 4, Players: map[string]*domain.Player{"bill": bill(8)}})
+	if !fourth.Delta || len(fourth.Players) != 0 || len(fourth.Removed) != 1 || fourth.Removed[0] != "jesse" {
+		t.Fatalf("expected a delta removing jesse, got %+v", fourth)
+	}
+
+	// A cowboy who missed a round asks for a snapshot.
+	resync, _ := game.NewEvent(game.EventResync, &domain.Resync{ID: "bill", Last: 2})
+	payload, _ := json.Marshal(resync)
+	master.handleMessage(&transport.Message{Payload: payload})
+
+	if fifth := receive(&domain.Round{Number: 5, Players: map[string]*domain.Player{"bill": bill(8)}}); fifth.Delta {
+		t.Fatalf("expected a snapshot after the resync request, got %+v", fifth)
+	}
+}
+
+func TestMasterAdmin(t *testing.T) {
+	cfg := &domain.MasterConfig{Players: 2, AdminToken: "secret"}
+	master := NewMaster(cfg, game.NewGame(cfg), clock.Real{}, log.New(io.Discard, "", 0), transport.NewMemory())
+	defer master.cancel()
+
+	tests := []struct {
+		name, token, path, body string
+		status                  int
+	}{
+		{"no token", "", "pause", "", http.StatusUnauthorized},
+		{"wrong token", "guess", "pause", "", http.StatusUnauthorized},
+		{"pause", "secret", "pause", "", http.StatusOK},
+		{"bad interval", "secret", "tick", `{"interval":"soon"}`, http.StatusBadRequest},
+		{"tick", "secret", "tick", `{"interval":"250ms"}`, http.StatusOK},
+		{"unknown cowboy", "secret", "kick", `{"id":"nobody"}`, http.StatusNotFound},
+		{"unknown command", "secret", "explode", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		request := httptest.NewRequest(http.MethodPost, adminPath+tt.path, strings.NewReader(tt.body))
+		if tt.token != "" {
+			request.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+
+		recorder := httptest.NewRecorder()
+		master.handleAdmin(recorder, request)
+
+		if recorder.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.status, recorder.Code)
+		}
+	}
+
+	if atomic.LoadInt32(&master.paused) != 1 {
+		t.Fatalf("expected the master to be paused")
+	}
+}
+
+// registrarFunc registers the cowboys straight with the master.
+type registrarFunc func(ctx context.Context, cfg *domain.PlayerConfig) (*domain.Player, error)
+
+func (f registrarFunc) Register(ctx context.Context, cfg *domain.PlayerConfig) (*domain.Player, error) {
+	return f(ctx, cfg)
+}
+
+func TestMasterFakeClock(t *testing.T) {
+	start := time.Unix(0, 0)
+	virtual := clock.NewFake(start)
+	cfg := &domain.MasterConfig{Players: 2, Port: "127.0.0.1:0", Tick: time.Second}
+	bus := transport.NewMemory()
+	master := NewMaster(cfg, game.NewGame(cfg), virtual, log.New(io.Discard, "", 0), bus)
+
+	published := bus.Subscribe(context.Background(), cfg.Key(masterTopic))
+	defer published.Close()
+
+	registrar := registrarFunc(func(_ context.Context, cfg *domain.PlayerConfig) (*domain.Player, error) {
+		return master.Register(cfg.Name, cfg.Health, cfg.Damage, 0, nil)
+	})
+
+	var stopped []chan struct{}
+	for _, cowboy := range []*domain.PlayerConfig{
+		{Name: "bill", Health: 100, Damage: 1, HeartbeatTimeout: time.Hour},
+		{Name: "jesse", Health: 120, Damage: 1, HeartbeatTimeout: time.Hour},
+	} {
+		player := NewPlayer(cowboy, strategy.Weakest{}, bus, registrar, virtual, log.New(io.Discard, "", 0))
+		done := make(chan struct{})
+		stopped = append(stopped, done)
+
+		go func() {
+			player.Run()
+			close(done)
+		}()
+	}
+
+	masterStopped := make(chan struct{})
+	go func() {
+		master.Run()
+		close(masterStopped)
+	}()
+
+	// Every tick of the virtual clock makes the master publish, the next tick waits for it.
+	var rounds int
+	var result domain.Result
+	for result.Winner == nil {
+		virtual.Advance(cfg.Tick)
+
+		select {
+		case msg := <-published.Channel():
+			var event game.Event
+			if err := json.Unmarshal(msg.Payload, &event); err != nil {
+				t.Fatalf("unmarshal published event: %v", err)
+			}
+
+			switch event.Type {
+			case game.EventRound:
+				rounds++
+			case game.EventGameOver:
+				_ = json.Unmarshal(event.Data, &result)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("nothing published after %d rounds", rounds)
+		}
+	}
+
+	if rounds < 100 || result.Winner.Name != "jesse" {
+		t.Fatalf("expected jesse to win after 100 rounds, got %s after %d rounds", result.Winner.Name, rounds)
+	}
+
+	if elapsed := virtual.Now().Sub(start); elapsed < 100*cfg.Tick {
+		t.Fatalf("expected the game to last 100 virtual seconds, got %s", elapsed)
+	}
+
+	<-masterStopped
+	for _, done := range stopped {
+		<-done
+	}
+}
+
+func TestMasterShotCooldownWithDefaultSpeed(t *testing.T) {
+	t.Setenv("NAME", "bill")
+
+	// The cowboys of a stock deployment register with the default speed.
+	var cowboy domain.PlayerConfig
+	if err := envconfig.Process("", &cowboy); err != nil {
+		t.Fatalf("process player config: %v", err)
+	}
+
+	cfg := &domain.MasterConfig{Players: 2, ShotCooldown: 2 * time.Second}
+	state := game.NewGame(cfg)
+	virtual := clock.NewFake(time.Unix(0, 0))
+	master := NewMaster(cfg, state, virtual, log.New(io.Discard, "", 0), transport.NewMemory())
+	defer master.cancel()
+
+	bill, err := master.Register(cowboy.Name, cowboy.Health, cowboy.Damage, cowboy.Speed, cowboy.Arm())
+	if err != nil {
+		t.Fatalf("register bill: %v", err)
+	}
+
+	if _, err := master.Register("jesse", cowboy.Health, cowboy.Damage, cowboy.Speed, cowboy.Arm()); err != nil {
+		t.Fatalf("register jesse: %v", err)
+	}
+
+	// A round every second grants a shot every other round only.
+	var shots []int
+	for i := 0; i < 4; i++ {
+		event, err := state.EmitEvent()
+		if err != nil || event.Type != game.EventRound {
+			t.Fatalf("expected a round, got %v: %v", event, err)
+		}
+
+		var round domain.Round
+		if err := json.Unmarshal(event.Data, &round); err != nil {
+			t.Fatalf("unmarshal round: %v", err)
+		}
+
+		shots = append(shots, round.Players[bill.ID].Shots)
+		virtual.Advance(time.Second)
+	}
+
+	if expected := []int{1, 0, 1, 0}; !reflect.DeepEqual(shots, expected) {
+		t.Fatalf("expected the shots %v, got %v", expected, shots)
+	}
+}
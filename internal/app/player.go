@@ -1,59 +1,114 @@
 package app
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/reactivejson/cowboys/internal/clock"
+	"github.com/reactivejson/cowboys/internal/codec"
 	"github.com/reactivejson/cowboys/internal/domain"
 	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/strategy"
+	"github.com/reactivejson/cowboys/internal/transport"
 	"log"
-	"net/http"
-	"net/url"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"time"
-
-	"github.com/go-redis/redis/v8"
 )
 
 const (
 	playerTopic = "player_events"
+
+	// shotBuffer is the number of actions the player queues for the round, so that deciding them does not
+	// wait for their publication.
+	shotBuffer = 16
 )
 
 var (
 	ErrUnexpectedEvent = fmt.Errorf("unexpected event received")
-	ErrNoTarget        = fmt.Errorf("no target found")
+	ErrNoTarget        = strategy.ErrNoTarget
+	ErrNoHeartbeat     = errors.New("no heartbeat from the master")
 )
 
 type Player struct {
-	ID          string
-	cfg         *domain.PlayerConfig
-	ctx         context.Context
-	cancel      context.CancelFunc
-	shotChan    chan *domain.Action
-	redisClient *redis.Client
-	logger      *log.Logger
+	ID        string
+	cfg       *domain.PlayerConfig
+	strategy  strategy.Strategy
+	ctx       context.Context
+	cancel    context.CancelFunc
+	shotChan  chan *domain.Action
+	transport transport.Transport
+	outbox    *transport.Outbox
+	wire      codec.Codec
+	registrar Registrar
+	clock     clock.Clock
+	logger    *log.Logger
+
+	// startAt is the local unix nano instant before which the player holds its fire.
+	startAt int64
+	// proposed are the IDs of the cowboys the player offered an alliance to.
+	proposed map[string]bool
+	// round is the last round the player knows, the base of the next delta.
+	round *domain.Round
 }
 
-func NewPlayer(cfg *domain.PlayerConfig, redisClient *redis.Client, logger *log.Logger) *Player {
+func NewPlayer(
+	cfg *domain.PlayerConfig,
+	strat strategy.Strategy,
+	bus transport.Transport,
+	registrar Registrar,
+	clk clock.Clock,
+	logger *log.Logger,
+) *Player {
 	ctx, cancelFn := signal.NotifyContext(context.Background(), os.Interrupt)
 
+	wire, err := codec.New(cfg.Codec)
+	if err != nil {
+		logger.Printf("%v, publishing JSON", err)
+		wire = codec.JSON{}
+	}
+
+	outbox := transport.NewOutbox(bus, &cfg.OutboxConfig, logger)
+
 	return &Player{
-		cfg:         cfg,
-		ctx:         ctx,
-		cancel:      cancelFn,
-		shotChan:    make(chan *domain.Action),
-		redisClient: redisClient,
-		logger:      logger,
+		cfg:       cfg,
+		strategy:  strat,
+		ctx:       ctx,
+		cancel:    cancelFn,
+		shotChan:  make(chan *domain.Action, shotBuffer),
+		transport: outbox,
+		outbox:    outbox,
+		wire:      wire,
+		registrar: registrar,
+		clock:     clk,
+		logger:    logger,
+		proposed:  make(map[string]bool),
 	}
 }
 
-func (p *Player) Run() {
+// ExitCode is the exit code of a player process once Run returned the error: a cowboy the game did not admit
+// exits with domain.ExitNotAdmitted, so that it is not restarted in vain.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, domain.ErrNotAdmitted):
+		return domain.ExitNotAdmitted
+	default:
+		return 1
+	}
+}
+
+// Run plays until the game is over for the cowboy. It fails when the master is lost, when the game does not
+// admit the cowboy, or on an event the player can not handle.
+func (p *Player) Run() error {
 	go p.fetchActions()
 
-	sub := p.redisClient.Subscribe(p.ctx, masterTopic)
+	sub := transport.Supervise(p.transport, p.cfg.Key(masterTopic), &p.cfg.SubscriptionConfig, p.logger)
 
+	var failure error
 	for {
 		select {
 		// finished
@@ -63,25 +118,40 @@ func (p *Player) Run() {
 			}
 
 			close(p.shotChan)
+			flush(p.outbox, p.logger)
 
-			return
-		// we expect to receive a message every second
+			return failure
+		// we expect to receive a message every tick
 		case msg := <-sub.Channel():
-			if err := p.handleMasterMessage(msg); err != nil {
+			if err := p.handleMasterMessage(msg); err != nil && failure == nil {
 				p.logger.Printf("handle message from master: %v", err)
+				failure = err
 				p.cancel()
 			}
+		// the rounds published while the subscription was down are lost
+		case <-sub.Gaps():
+			if err := p.resync(); err != nil {
+				p.logger.Printf("ask for a snapshot: %v", err)
+			}
 		// communication is lost
-		case <-time.After(time.Second * 2):
-			p.logger.Printf("no heartbeat")
-			p.cancel()
+		case <-p.clock.After(p.cfg.Heartbeat()):
+			if down := sub.Down(); down > 0 && down < p.cfg.ResubscribeTimeout {
+				p.logger.Printf("no heartbeat, waiting for the subscription down for %s", down.Round(time.Millisecond))
+				continue
+			}
+
+			if failure == nil {
+				p.logger.Printf("no heartbeat")
+				failure = ErrNoHeartbeat
+				p.cancel()
+			}
 		}
 	}
 }
 
-func (p *Player) handleMasterMessage(msg *redis.Message) error {
-	var event game.Event
-	if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+func (p *Player) handleMasterMessage(msg *transport.Message) error {
+	event, err := codec.Unmarshal(msg.Payload)
+	if err != nil {
 		return fmt.Errorf("unmarshal payload: %w", err)
 	}
 
@@ -91,20 +161,58 @@ func (p *Player) handleMasterMessage(msg *redis.Message) error {
 			return p.join()
 		}
 
+		return nil
+	case game.EventReadyCheck:
+		// The game is full without the cowboy.
+		if p.ID == "" {
+			return fmt.Errorf("ready check: %w", domain.ErrNotAdmitted)
+		}
+
+		// Receiving the check proves the subscription, so acknowledge it.
+		event, err := game.NewEvent(game.EventReady, &domain.Ready{ID: p.ID})
+		if err != nil {
+			return fmt.Errorf("create ready event: %w", err)
+		}
+
+		return p.publish(event)
+	case game.EventCountdown:
+		var countdown domain.Countdown
+		if err := json.Unmarshal(event.Data, &countdown); err != nil {
+			return fmt.Errorf("unmarshal countdown: %w", err)
+		}
+
+		receivedAt := p.clock.Now()
+		start := countdown.LocalStart(receivedAt)
+		if atomic.SwapInt64(&p.startAt, start.UnixNano()) == 0 {
+			p.logger.Printf("encounter starts in %s (clock offset %s)", start.Sub(receivedAt), countdown.Offset(receivedAt))
+		}
+
 		return nil
 	case game.EventRound:
+		// The game started without the cowboy.
 		if p.ID == "" {
-			return ErrUnexpectedEvent
+			return fmt.Errorf("round: %w", domain.ErrNotAdmitted)
 		}
 
-		var round domain.Round
-		if err := json.Unmarshal(event.Data, &round); err != nil {
+		var update domain.Round
+		if err := json.Unmarshal(event.Data, &update); err != nil {
 			return fmt.Errorf("unmarshal competitors: %w", err)
 		}
 
-		win, ok := round.Players[p.ID]
+		round, err := p.track(&update)
+		if err != nil || round == nil {
+			return err
+		}
+
+		if len(round.Players) == 0 {
+			log.Println("Nobody is standing -> DRAW :|")
+			p.cancel()
+			return nil
+		}
+
+		me, ok := round.Players[p.ID]
 		if len(round.Players) == 1 && ok {
-			log.Println("I am the Winner:) ", win.Name, "My health", win.Health)
+			log.Println("I am the Winner:) ", me.Name, "My health", me.Health)
 			p.cancel()
 			return nil
 		}
@@ -115,59 +223,177 @@ func (p *Player) handleMasterMessage(msg *redis.Message) error {
 			return nil
 		}
 
-		for target := range round.Players {
-			if target != p.ID {
-				p.shotChan <- &domain.Action{
-					Src:  p.ID,
-					Dest: target,
-				}
+		if err := p.propose(round); err != nil {
+			return err
+		}
+
+		shots := 1
+		if me.Speed > 0 {
+			// The cooldown timeline of the cowboy decides how many shots it fires in the round.
+			shots = me.Shots
+		}
+
+		if me.Reloading || shots < 1 {
+			return nil
+		}
+
+		if p.shouldReload(me) {
+			event, err := game.NewEvent(game.EventReload, &domain.Reload{ID: p.ID})
+			if err != nil {
+				return fmt.Errorf("create reload event: %w", err)
+			}
+
+			return p.publish(event)
+		}
 
+		action, err := strategy.Decide(p.ctx, p.strategy, p.ID, round)
+		if err != nil {
+			return fmt.Errorf("pick target: %w", err)
+		}
+
+		action.Round = round.Number
+
+		// Any action but a shot takes the whole round.
+		if action.Type() != domain.ActionShoot {
+			shots = 1
+		}
+
+		for ; shots > 0; shots-- {
+			// Nobody fetches the actions once the player stopped.
+			select {
+			case p.shotChan <- action:
+			case <-p.ctx.Done():
 				return nil
 			}
 		}
 
-		return ErrNoTarget
+		return nil
+	case game.EventMessage:
+		var message domain.Message
+		if err := json.Unmarshal(event.Data, &message); err != nil {
+			return fmt.Errorf("unmarshal message: %w", err)
+		}
+
+		if message.From != p.ID && (message.To == "" || message.To == p.ID) {
+			p.logger.Printf("message from %s: %s", message.From, message.Text)
+		}
+
+		return nil
+	case game.EventAlliance:
+		var alliance domain.Alliance
+		if err := json.Unmarshal(event.Data, &alliance); err != nil {
+			return fmt.Errorf("unmarshal alliance: %w", err)
+		}
+
+		if alliance.To != p.ID {
+			return nil
+		}
+
+		p.logger.Printf("alliance %s from %s", alliance.Kind, alliance.From)
+
+		if alliance.Kind != domain.AlliancePropose || !p.cfg.AcceptAlliances {
+			return nil
+		}
+
+		return p.diplomacy(&domain.Alliance{From: p.ID, To: alliance.From, Kind: domain.AllianceAccept})
+	case game.EventGameOver:
+		var result domain.Result
+		if err := json.Unmarshal(event.Data, &result); err != nil {
+			return fmt.Errorf("unmarshal result: %w", err)
+		}
+
+		if result.Reason != "" {
+			log.Println("Game over:", result.Reason)
+		}
+
+		p.cancel()
+		return nil
 	default:
 		return fmt.Errorf("unknown event %q received", event.Type)
 	}
 }
 
-func (p *Player) join() error {
-	masterURL, err := url.Parse(p.cfg.MasterAddr)
-	if err != nil {
-		return fmt.Errorf("parse master url: %w", err)
+// track folds a round update into the last round the player knows. It returns nil for a round already seen,
+// and for a delta following a round the player missed, asking the master for a snapshot instead.
+func (p *Player) track(update *domain.Round) (*domain.Round, error) {
+	if p.round != nil && update.Number <= p.round.Number {
+		return nil, nil
 	}
 
-	masterURL.Path = registerPath
+	round, err := p.round.Apply(update)
+	if errors.Is(err, domain.ErrMissedRound) {
+		p.logger.Printf("missed the rounds between %d and %d, asking for a snapshot", p.lastRound(), update.Number)
 
-	payload, err := json.Marshal(&registrationRequest{
-		Name:   p.cfg.Name,
-		Health: p.cfg.Health,
-		Damage: p.cfg.Damage,
-	})
-	if err != nil {
-		return fmt.Errorf("marshal registration request body: %w", err)
+		return nil, p.resync()
 	}
 
-	req, err := http.NewRequest(http.MethodPost, masterURL.String(), bytes.NewReader(payload))
-	if err != nil {
-		return fmt.Errorf("create registration request: %w", err)
+	p.round = round
+
+	return round, nil
+}
+
+// resync asks the master for a snapshot, the rounds after the last one the player knows being lost.
+func (p *Player) resync() error {
+	if p.ID == "" {
+		return nil
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	event, err := game.NewEvent(game.EventResync, &domain.Resync{ID: p.ID, Last: p.lastRound()})
 	if err != nil {
-		return fmt.Errorf("send registration request: %w", err)
+		return fmt.Errorf("create resync event: %w", err)
+	}
+
+	return p.publish(event)
+}
+
+// lastRound returns the number of the last round the player knows, 0 before the first one.
+func (p *Player) lastRound() int {
+	if p.round == nil {
+		return 0
+	}
+
+	return p.round.Number
+}
+
+// propose offers an alliance to the living cowboys named in the configuration, once.
+func (p *Player) propose(round *domain.Round) error {
+	for _, name := range p.cfg.Allies {
+		for id, player := range round.Players {
+			if player.Name != name || id == p.ID || p.proposed[id] || round.Players[p.ID].IsAlly(id) {
+				continue
+			}
+
+			p.proposed[id] = true
+
+			if err := p.diplomacy(&domain.Alliance{From: p.ID, To: id, Kind: domain.AlliancePropose}); err != nil {
+				return err
+			}
+		}
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected registration response code %d", resp.StatusCode)
+	return nil
+}
+
+func (p *Player) diplomacy(alliance *domain.Alliance) error {
+	event, err := game.NewEvent(game.EventAlliance, alliance)
+	if err != nil {
+		return fmt.Errorf("create alliance event: %w", err)
 	}
 
-	var competitor domain.Player
-	if err := json.NewDecoder(resp.Body).Decode(&competitor); err != nil {
-		return fmt.Errorf("decode registration response: %w", err)
+	return p.publish(event)
+}
+
+// shouldReload reports whether the player reloads this round instead of shooting, once its ammo
+// fell to the configured threshold.
+func (p *Player) shouldReload(self *domain.Player) bool {
+	return self.Weapon != nil && self.Weapon.Magazine > 0 && self.Ammo <= p.cfg.ReloadAt
+}
+
+func (p *Player) join() error {
+	competitor, err := p.registrar.Register(p.ctx, p.cfg)
+	if err != nil {
+		return err
 	}
-	defer resp.Body.Close()
 
 	p.ID = competitor.ID
 
@@ -176,24 +402,46 @@ func (p *Player) join() error {
 
 func (p *Player) fetchActions() {
 	for shot := range p.shotChan {
-		event, err := game.NewEvent(game.EventShot, shot)
-		if err != nil {
-			p.logger.Printf("create shot event: %v", err)
-			p.cancel()
+		if !p.holdFire() {
 			return
 		}
 
-		payload, err := json.Marshal(event)
+		event, err := game.NewEvent(game.EventAction, shot)
 		if err != nil {
-			p.logger.Printf("marshal shot event: %v", err)
+			p.logger.Printf("create action event: %v", err)
 			p.cancel()
 			return
 		}
 
-		if err := p.redisClient.Publish(p.ctx, playerTopic, payload).Err(); err != nil {
-			p.logger.Printf("publish shot event: %v", err)
-			p.cancel()
-			return
+		// The outbox retries a failed publish, an action it gave up on is lost but the cowboy plays on.
+		if err := p.publish(event); err != nil {
+			p.logger.Printf("publish action event: %v", err)
 		}
 	}
 }
+
+// holdFire waits for the announced start instant. It returns false if the player stopped meanwhile.
+func (p *Player) holdFire() bool {
+	wait := time.Unix(0, atomic.LoadInt64(&p.startAt)).Sub(p.clock.Now())
+	if wait <= 0 {
+		return true
+	}
+
+	select {
+	case <-p.clock.After(wait):
+		return true
+	case <-p.ctx.Done():
+		return false
+	}
+}
+
+func (p *Player) publish(event *game.Event) error {
+	event.Game = p.cfg.GameID
+
+	payload, err := p.wire.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", event.Type, err)
+	}
+
+	return p.transport.Publish(p.ctx, p.cfg.Key(playerTopic), payload)
+}
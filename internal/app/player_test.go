@@ -0,0 +1,286 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/clock"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/strategy"
+	"github.com/reactivejson/cowboys/internal/transport"
+)
+
+func TestPlayerResync(t *testing.T) {
+	cfg := &domain.PlayerConfig{Name: "bill"}
+	bus := transport.NewMemory()
+	player := NewPlayer(cfg, strategy.Weakest{}, bus, nil, clock.Real{}, log.New(io.Discard, "", 0))
+	defer player.cancel()
+
+	player.ID = "bill"
+	player.round = &domain.Round{Number: 4, Players: map[string]*domain.Player{
+		"bill":  {ID: "bill", Health: 10, Damage: 1},
+		"jesse": {ID: "jesse", Health: 5, Damage: 2},
+	}}
+
+	requests := bus.Subscribe(player.ctx, cfg.Key(playerTopic))
+	defer requests.Close()
+
+	deliver := func(round *domain.Round) {
+		t.Helper()
+
+		event, _ := game.NewEvent(game.EventRound, round)
+		payload, _ := json.Marshal(event)
+		if err := player.handleMasterMessage(&transport.Message{Payload: payload}); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+
+	// A round seen already is ignored.
+	deliver(&domain.Round{Number: 4, Delta: true})
+
+	// The delta of round 6 does not apply to round 4.
+	deliver(&domain.Round{Number: 6, Delta: true, Players: map[string]*domain.Player{}})
+
+	select {
+	case msg := <-requests.Channel():
+		var event game.Event
+		var resync domain.Resync
+		if err := json.Unmarshal(msg.Payload, &event); err != nil || json.Unmarshal(event.Data, &resync) != nil {
+			t.Fatalf("unmarshal request: %s", msg.Payload)
+		}
+
+		if event.Type != game.EventResync || resync.ID != "bill" || resync.Last != 4 {
+			t.Fatalf("expected bill to ask for a snapshot after round 4, got %s %+v", event.Type, resync)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no resync requested")
+	}
+
+	select {
+	case msg := <-requests.Channel():
+		t.Fatalf("unexpected request %s", msg.Payload)
+	default:
+	}
+
+	// The snapshot of the winning round replaces the rounds the player missed.
+	deliver(&domain.Round{Number: 7, Players: map[string]*domain.Player{"bill": {ID: "bill", Health: 3, Damage: 1}}})
+
+	if player.round.Number != 7 || player.ctx.Err() == nil {
+		t.Fatalf("expected bill to win on the snapshot of round 7, got round %d", player.round.Number)
+	}
+}
+
+// blip fails the first publishes, like a Redis restarting.
+type blip struct {
+	transport.Transport
+
+	failures int32
+}
+
+func (b *blip) Publish(ctx context.Context, topic string, payload []byte) error {
+	if atomic.AddInt32(&b.failures, -1) >= 0 {
+		return errors.New("connection refused")
+	}
+
+	return b.Transport.Publish(ctx, topic, payload)
+}
+
+func TestPlayerSurvivesPublishFailures(t *testing.T) {
+	cfg := &domain.PlayerConfig{Name: "bill", OutboxConfig: domain.OutboxConfig{OutboxRetries: 3, OutboxBackoff: time.Millisecond}}
+	bus := transport.NewMemory()
+	player := NewPlayer(cfg, strategy.Weakest{}, &blip{Transport: bus, failures: 2}, nil, clock.Real{}, log.New(io.Discard, "", 0))
+	defer player.cancel()
+
+	player.ID = "bill"
+
+	requests := bus.Subscribe(player.ctx, cfg.Key(playerTopic))
+	defer requests.Close()
+
+	check, _ := json.Marshal(&game.Event{Type: game.EventReadyCheck})
+	if err := player.handleMasterMessage(&transport.Message{Payload: check}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	select {
+	case msg := <-requests.Channel():
+		var event game.Event
+		if err := json.Unmarshal(msg.Payload, &event); err != nil || event.Type != game.EventReady {
+			t.Fatalf("expected the ready event, got %s", msg.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no ready event published")
+	}
+
+	if player.ctx.Err() != nil {
+		t.Fatal("expected the player to keep playing through the failed publishes")
+	}
+}
+
+// severable hands out in-memory subscriptions it can break, like a Redis failing over.
+type severable struct {
+	*transport.Memory
+
+	lock          sync.Mutex
+	subscriptions []transport.Subscription
+}
+
+func (s *severable) Subscribe(ctx context.Context, topic string) transport.Subscription {
+	subscription := s.Memory.Subscribe(ctx, topic)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.subscriptions = append(s.subscriptions, subscription)
+
+	return subscription
+}
+
+func (s *severable) sever() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, subscription := range s.subscriptions {
+		_ = subscription.Close()
+	}
+
+	s.subscriptions = nil
+}
+
+func TestPlayerResyncsAfterGap(t *testing.T) {
+	cfg := &domain.PlayerConfig{Name: "bill", ResubscribeTimeout: time.Minute}
+	cfg.ResubscribeBackoff = time.Millisecond
+	bus := &severable{Memory: transport.NewMemory()}
+	player := NewPlayer(cfg, strategy.Weakest{}, bus, nil, clock.Real{}, log.New(io.Discard, "", 0))
+	player.ID = "bill"
+	player.round = &domain.Round{Number: 4, Players: map[string]*domain.Player{"bill": {ID: "bill", Health: 10, Damage: 1}}}
+
+	requests := bus.Memory.Subscribe(player.ctx, cfg.Key(playerTopic))
+	defer requests.Close()
+
+	stopped := make(chan struct{})
+	go func() {
+		player.Run()
+		close(stopped)
+	}()
+
+	// Wait for the player to subscribe, then cut it off.
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		bus.lock.Lock()
+		subscribed := len(bus.subscriptions) > 0
+		bus.lock.Unlock()
+
+		if subscribed {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("the player did not subscribe")
+		}
+	}
+
+	bus.sever()
+
+	select {
+	case msg := <-requests.Channel():
+		var event game.Event
+		var resync domain.Resync
+		if err := json.Unmarshal(msg.Payload, &event); err != nil || json.Unmarshal(event.Data, &resync) != nil {
+			t.Fatalf("unmarshal request: %s", msg.Payload)
+		}
+
+		if event.Type != game.EventResync || resync.ID != "bill" || resync.Last != 4 {
+			t.Fatalf("expected bill to ask for a snapshot after round 4, got %s %+v", event.Type, resync)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no resync requested after the gap")
+	}
+
+	player.cancel()
+	<-stopped
+}
+
+func TestPlayerStopsQueueingShots(t *testing.T) {
+	cfg := &domain.PlayerConfig{Name: "bill"}
+	player := NewPlayer(cfg, strategy.Weakest{}, transport.NewMemory(), nil, clock.Real{}, log.New(io.Discard, "", 0))
+	player.ID = "bill"
+
+	// Nobody fetches the actions anymore and the queue is full.
+	for i := 0; i < shotBuffer; i++ {
+		player.shotChan <- &domain.Action{Src: "bill"}
+	}
+
+	player.cancel()
+
+	event, _ := game.NewEvent(game.EventRound, &domain.Round{Number: 1, Players: map[string]*domain.Player{
+		"bill":  {ID: "bill", Health: 10, Damage: 1, Speed: 10, Shots: 10},
+		"jesse": {ID: "jesse", Health: 5, Damage: 2},
+	}})
+	payload, _ := json.Marshal(event)
+
+	handled := make(chan error, 1)
+	go func() {
+		handled <- player.handleMasterMessage(&transport.Message{Payload: payload})
+	}()
+
+	select {
+	case err := <-handled:
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a stopped player not to block on its shots")
+	}
+}
+
+func TestPlayerExitCodes(t *testing.T) {
+	cfg := &domain.PlayerConfig{Name: "bill", HeartbeatTimeout: 10 * time.Millisecond}
+	player := NewPlayer(cfg, strategy.Weakest{}, transport.NewMemory(), nil, clock.Real{}, log.New(io.Discard, "", 0))
+
+	err := player.Run()
+	if !errors.Is(err, ErrNoHeartbeat) || ExitCode(err) != 1 {
+		t.Fatalf("expected a failure without heartbeat, got %v exiting with %d", err, ExitCode(err))
+	}
+
+	// A cowboy joining a game that started without it is not restarted.
+	cfg = &domain.PlayerConfig{Name: "jesse", HeartbeatTimeout: time.Minute}
+	bus := transport.NewMemory()
+	refused := registrarFunc(func(context.Context, *domain.PlayerConfig) (*domain.Player, error) {
+		return nil, domain.ErrNotAdmitted
+	})
+	player = NewPlayer(cfg, strategy.Weakest{}, bus, refused, clock.Real{}, log.New(io.Discard, "", 0))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- player.Run()
+	}()
+
+	heartbeat, _ := game.NewEvent(game.Heartbeat, nil)
+	payload, _ := json.Marshal(heartbeat)
+
+	for {
+		select {
+		case err := <-done:
+			if !errors.Is(err, domain.ErrNotAdmitted) || ExitCode(err) != domain.ExitNotAdmitted {
+				t.Fatalf("expected the cowboy not to be admitted, got %v exiting with %d", err, ExitCode(err))
+			}
+
+			if ExitCode(nil) != 0 {
+				t.Fatal("expected a player playing the game out to exit with 0")
+			}
+
+			return
+		case <-time.After(10 * time.Millisecond):
+			if err := bus.Publish(context.Background(), cfg.Key(masterTopic), payload); err != nil {
+				t.Fatalf("publish heartbeat: %v", err)
+			}
+		}
+	}
+}
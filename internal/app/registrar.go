@@ -0,0 +1,77 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// Registrar joins a cowboy to the game of the master.
+type Registrar interface {
+	Register(ctx context.Context, cfg *domain.PlayerConfig) (*domain.Player, error)
+}
+
+// HTTPRegistrar registers through the HTTP endpoint of the master.
+type HTTPRegistrar struct {
+	masterAddr string
+	client     *http.Client
+}
+
+func NewHTTPRegistrar(masterAddr string) *HTTPRegistrar {
+	return &HTTPRegistrar{
+		masterAddr: masterAddr,
+		client:     http.DefaultClient,
+	}
+}
+
+func (r *HTTPRegistrar) Register(ctx context.Context, cfg *domain.PlayerConfig) (*domain.Player, error) {
+	masterURL, err := url.Parse(r.masterAddr)
+	if err != nil {
+		return nil, fmt.Errorf("parse master url: %w", err)
+	}
+
+	masterURL.Path = registerPath
+
+	payload, err := json.Marshal(&registrationRequest{
+		Name:   cfg.Name,
+		Health: cfg.Health,
+		Damage: cfg.Damage,
+		Speed:  cfg.Speed,
+		Weapon: cfg.Arm(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal registration request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, masterURL.String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("create registration request: %w", err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send registration request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The game started or ended without the cowboy.
+	if resp.StatusCode == http.StatusConflict {
+		return nil, fmt.Errorf("register %s: %w", cfg.Name, domain.ErrNotAdmitted)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected registration response code %d", resp.StatusCode)
+	}
+
+	var competitor domain.Player
+	if err := json.NewDecoder(resp.Body).Decode(&competitor); err != nil {
+		return nil, fmt.Errorf("decode registration response: %w", err)
+	}
+
+	return &competitor, nil
+}
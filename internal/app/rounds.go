@@ -0,0 +1,44 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// roundEncoder turns the rounds published over Redis into deltas, with a full snapshot every `every` rounds and
+// after a player asked for one. Only the game loop encodes rounds, resync requests come from any goroutine.
+type roundEncoder struct {
+	every  int
+	prev   *domain.Round
+	resync int32
+}
+
+// encode returns the payload to publish for a round, a delta when the previous round was published too.
+func (e *roundEncoder) encode(data json.RawMessage) (json.RawMessage, error) {
+	if e.every <= 1 {
+		return data, nil
+	}
+
+	var round domain.Round
+	if err := json.Unmarshal(data, &round); err != nil {
+		return nil, fmt.Errorf("unmarshal round: %w", err)
+	}
+
+	prev := e.prev
+	e.prev = &round
+
+	resync := atomic.SwapInt32(&e.resync, 0) == 1
+	if resync || prev == nil || round.Number%e.every == 0 || round.Number != prev.Number+1 {
+		return data, nil
+	}
+
+	return json.Marshal(round.Diff(prev))
+}
+
+// snapshot makes the next round a full snapshot.
+func (e *roundEncoder) snapshot() {
+	atomic.StoreInt32(&e.resync, 1)
+}
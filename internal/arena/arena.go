@@ -0,0 +1,255 @@
+package arena
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/roster"
+)
+
+var (
+	ErrNoResult     = errors.New("the master exited without a result")
+	errMasterExited = errors.New("master exited before listening")
+)
+
+// RestartPolicy decides whether a player process is started again once it exited.
+type RestartPolicy string
+
+const (
+	// RestartNever leaves exited players alone.
+	RestartNever RestartPolicy = "never"
+	// RestartOnFailure restarts players exiting with an error, up to MaxRestarts times, until the game goes on
+	// without them.
+	RestartOnFailure RestartPolicy = "on-failure"
+)
+
+// Valid reports whether the policy is a known one.
+func (p RestartPolicy) Valid() bool {
+	return p == RestartNever || p == RestartOnFailure
+}
+
+// Config describes the processes of an arena.
+type Config struct {
+	Roster *roster.Roster
+	// MasterBin and PlayerBin are the executables of the master and of the players.
+	MasterBin, PlayerBin string
+	// Port is the HTTP port of the master, e.g. ":8080".
+	Port        string
+	Restart     RestartPolicy
+	MaxRestarts int
+	// Env is the environment every process inherits, before its own variables.
+	Env []string
+	// Out receives the logs of every process, prefixed with its name.
+	Out io.Writer
+	// StartTimeout bounds the wait for the master to listen, StopTimeout the graceful stop of a process.
+	StartTimeout, StopTimeout time.Duration
+}
+
+// Arena runs one master and one player process per roster entry on the local machine.
+type Arena struct {
+	cfg    *Config
+	logger *log.Logger
+	out    *multiplexer
+}
+
+func New(cfg *Config, logger *log.Logger) *Arena {
+	return &Arena{
+		cfg:    cfg,
+		logger: logger,
+		out:    newMultiplexer(cfg.Out),
+	}
+}
+
+// Run plays a game and returns its result, once the master exited.
+func (a *Arena) Run(ctx context.Context) (*domain.Result, error) {
+	dir, err := os.MkdirTemp("", "cowboys-arena")
+	if err != nil {
+		return nil, fmt.Errorf("create arena directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	resultFile := filepath.Join(dir, "result.json")
+
+	master := a.command("master", a.cfg.MasterBin,
+		"PORT="+a.cfg.Port,
+		"COMPETITORS="+strconv.Itoa(len(a.cfg.Roster.Players)),
+		"RESULT_FILE="+resultFile,
+	)
+	if err := master.Start(); err != nil {
+		return nil, fmt.Errorf("start master: %w", err)
+	}
+
+	masterDone := make(chan error, 1)
+	go func() {
+		masterDone <- master.Wait()
+	}()
+
+	if err := a.waitListening(ctx, masterDone); err != nil {
+		if !errors.Is(err, errMasterExited) {
+			a.stop(master, masterDone)
+		}
+
+		return nil, err
+	}
+
+	playersCtx, stopPlayers := context.WithCancel(ctx)
+	defer stopPlayers()
+
+	var players sync.WaitGroup
+	for _, entry := range a.cfg.Roster.Players {
+		players.Add(1)
+
+		go func(entry *roster.Entry) {
+			defer players.Done()
+			a.supervise(playersCtx, entry)
+		}(entry)
+	}
+
+	select {
+	case err = <-masterDone:
+	case <-ctx.Done():
+		err = a.stop(master, masterDone)
+	}
+
+	stopPlayers()
+	players.Wait()
+
+	if err != nil {
+		a.logger.Printf("master exited: %v", err)
+	}
+
+	return readResult(resultFile)
+}
+
+// supervise runs the process of a player, restarting it according to the policy until the game is over.
+func (a *Arena) supervise(ctx context.Context, entry *roster.Entry) {
+	for restarts := 0; ; restarts++ {
+		player := a.command(entry.Name, a.cfg.PlayerBin,
+			append(entry.Env(), "MASTER_ADDR=http://"+a.masterAddr())...,
+		)
+
+		if err := player.Start(); err != nil {
+			a.logger.Printf("start player %s: %v", entry.Name, err)
+			return
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- player.Wait()
+		}()
+
+		var err error
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			a.stop(player, done)
+			return
+		}
+
+		if err == nil || a.cfg.Restart != RestartOnFailure {
+			return
+		}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == domain.ExitNotAdmitted {
+			a.logger.Printf("player %s was not admitted, the game goes on without it", entry.Name)
+			return
+		}
+
+		if restarts >= a.cfg.MaxRestarts {
+			a.logger.Printf("player %s crashed: %v, giving up after %d restarts", entry.Name, err, restarts)
+			return
+		}
+
+		a.logger.Printf("player %s crashed: %v, restarting it", entry.Name, err)
+	}
+}
+
+// command prepares a process whose output goes to the multiplexer under the given name.
+// The process is not bound to a context, it is stopped gracefully instead.
+func (a *Arena) command(name, bin string, env ...string) *exec.Cmd {
+	cmd := exec.Command(bin)
+	cmd.Env = append(append([]string{}, a.cfg.Env...), env...)
+	cmd.Stdout = a.out.writer(name)
+	cmd.Stderr = cmd.Stdout
+
+	return cmd
+}
+
+// stop interrupts a process, then kills it if it did not exit within the stop timeout.
+func (a *Arena) stop(cmd *exec.Cmd, done <-chan error) error {
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		return <-done
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(a.cfg.StopTimeout):
+		if err := cmd.Process.Kill(); err != nil {
+			a.logger.Printf("kill %s: %v", cmd.Path, err)
+		}
+
+		return <-done
+	}
+}
+
+// waitListening waits for the master to accept connections, so that the players can register.
+func (a *Arena) waitListening(ctx context.Context, masterDone <-chan error) error {
+	ctx, cancel := context.WithTimeout(ctx, a.cfg.StartTimeout)
+	defer cancel()
+
+	for {
+		conn, err := net.DialTimeout("tcp", a.masterAddr(), time.Second)
+		if err == nil {
+			return conn.Close()
+		}
+
+		select {
+		case exitErr := <-masterDone:
+			return fmt.Errorf("%w: %v", errMasterExited, exitErr)
+		case <-ctx.Done():
+			return fmt.Errorf("master not listening on %s: %w", a.cfg.Port, ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func (a *Arena) masterAddr() string {
+	host, port, err := net.SplitHostPort(a.cfg.Port)
+	if err != nil || host == "" {
+		return net.JoinHostPort("localhost", port)
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
+func readResult(path string) (*domain.Result, error) {
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoResult
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read result: %w", err)
+	}
+
+	var result domain.Result
+	if err := json.Unmarshal(payload, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal result: %w", err)
+	}
+
+	return &result, nil
+}
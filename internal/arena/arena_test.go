@@ -0,0 +1,213 @@
+package arena
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/app"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/roster"
+)
+
+// The test binary stands in for the master and the players when the helper variable is set.
+const helperEnv = "COWBOYS_ARENA_HELPER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(helperEnv) != "" {
+		if os.Getenv("NAME") != "" {
+			fakePlayer()
+		} else {
+			fakeMaster()
+		}
+
+		return
+	}
+
+	os.Exit(m.Run())
+}
+
+// fakeMaster waits for every cowboy to join and declares the first one the winner. The game then goes on for
+// LINGER, refusing the cowboys joining late like the real master.
+func fakeMaster() {
+	var (
+		lock   sync.Mutex
+		joined []*domain.Player
+		done   = make(chan struct{})
+	)
+
+	competitors := 0
+	fmt.Sscan(os.Getenv("COMPETITORS"), &competitors)
+
+	http.HandleFunc("/join", func(w http.ResponseWriter, r *http.Request) {
+		var request domain.Player
+		_ = json.NewDecoder(r.Body).Decode(&request)
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		if len(joined) == competitors {
+			fmt.Println("refused", request.Name)
+			http.Error(w, "conflict", http.StatusConflict)
+
+			return
+		}
+
+		player := &domain.Player{ID: request.Name, Name: request.Name, Health: request.Health, Damage: request.Damage}
+		joined = append(joined, player)
+		fmt.Println("joined", len(joined))
+		_ = json.NewEncoder(w).Encode(player)
+
+		if len(joined) == competitors {
+			close(done)
+		}
+	})
+
+	go http.ListenAndServe(os.Getenv("PORT"), nil)
+	<-done
+
+	linger, _ := time.ParseDuration(os.Getenv("LINGER"))
+	time.Sleep(linger)
+
+	payload, _ := json.Marshal(&domain.Result{Winner: joined[0], Standings: joined})
+	os.WriteFile(os.Getenv("RESULT_FILE"), payload, 0o600)
+}
+
+// fakePlayer registers like the real player and exits with its exit codes. On its first run, the crashing cowboy
+// crashes before joining, and the dropout right after joining.
+func fakePlayer() {
+	name := os.Getenv("NAME")
+
+	crashed := filepath.Join(os.Getenv("CRASH_DIR"), name)
+	_, err := os.Stat(crashed)
+	firstRun := err != nil
+	os.WriteFile(crashed, nil, 0o600)
+
+	if name == "crashy" && firstRun {
+		fmt.Println("crashing")
+		os.Exit(1)
+	}
+
+	registrar := app.NewHTTPRegistrar(os.Getenv("MASTER_ADDR"))
+	if _, err := registrar.Register(context.Background(), &domain.PlayerConfig{Name: name, Health: 1, Damage: 1}); err != nil {
+		fmt.Println(err)
+		os.Exit(app.ExitCode(err))
+	}
+
+	fmt.Println("joined as", name)
+
+	if name == "dropout" && firstRun {
+		fmt.Println("crashing")
+		os.Exit(1)
+	}
+}
+
+func freePort(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func newTestArena(t *testing.T, restart RestartPolicy, out io.Writer, players ...string) *Arena {
+	t.Helper()
+
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatalf("test executable: %v", err)
+	}
+
+	var entries []*roster.Entry
+	for _, name := range players {
+		entries = append(entries, &roster.Entry{Name: name, Health: 3, Damage: 1})
+	}
+
+	return New(&Config{
+		Roster:      &roster.Roster{Players: entries},
+		MasterBin:   self,
+		PlayerBin:   self,
+		Port:        freePort(t),
+		Restart:     restart,
+		MaxRestarts: 1,
+		// The game goes on a little after the last cowboy joined, for it to log it.
+		Env:          []string{helperEnv + "=1", "CRASH_DIR=" + t.TempDir(), "LINGER=200ms"},
+		Out:          out,
+		StartTimeout: 5 * time.Second,
+		StopTimeout:  time.Second,
+	}, log.New(io.Discard, "", 0))
+}
+
+func TestArenaRestartsCrashedPlayers(t *testing.T) {
+	var out bytes.Buffer
+	arena := newTestArena(t, RestartOnFailure, &out, "first", "crashy")
+
+	result, err := arena.Run(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if result.Winner == nil || len(result.Standings) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	logs := out.String()
+	for _, line := range []string{"crashy | crashing", "crashy | joined as crashy", "first  | joined as first", "master | joined 2"} {
+		if !strings.Contains(logs, line) {
+			t.Errorf("expected %q in the logs:\n%s", line, logs)
+		}
+	}
+}
+
+func TestArenaStopsWithoutResult(t *testing.T) {
+	arena := newTestArena(t, RestartNever, io.Discard, "first", "crashy")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// The crashing cowboy never joins, so the game never ends.
+	if _, err := arena.Run(ctx); err != ErrNoResult {
+		t.Fatalf("expected ErrNoResult, got: %v", err)
+	}
+}
+
+func TestArenaDoesNotRestartPlayersNotAdmitted(t *testing.T) {
+	var out bytes.Buffer
+	arena := newTestArena(t, RestartOnFailure, &out, "first", "dropout")
+	arena.cfg.MaxRestarts = 3
+	arena.cfg.Env = append(arena.cfg.Env, "LINGER=1s")
+
+	result, err := arena.Run(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if result.Winner == nil || len(result.Standings) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	// Restarted after crashing mid-game, the dropout is refused once and left out.
+	logs := out.String()
+	if refused := strings.Count(logs, "| refused dropout"); refused != 1 {
+		t.Fatalf("expected the dropout to be refused once, got %d times:\n%s", refused, logs)
+	}
+
+	if !strings.Contains(logs, "| register dropout: "+domain.ErrNotAdmitted.Error()) {
+		t.Fatalf("expected the dropout not to be admitted:\n%s", logs)
+	}
+}
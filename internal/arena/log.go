@@ -0,0 +1,64 @@
+package arena
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// multiplexer interleaves the output of the processes line by line, each line prefixed with the process name.
+type multiplexer struct {
+	out   io.Writer
+	lock  sync.Mutex
+	width int
+}
+
+func newMultiplexer(out io.Writer) *multiplexer {
+	return &multiplexer{out: out}
+}
+
+// writer returns the writer of a process.
+func (m *multiplexer) writer(name string) io.Writer {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if len(name) > m.width {
+		m.width = len(name)
+	}
+
+	return &prefixWriter{multiplexer: m, name: name}
+}
+
+func (m *multiplexer) writeLine(name string, line []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	_, err := fmt.Fprintf(m.out, "%-*s | %s\n", m.width, name, line)
+
+	return err
+}
+
+// prefixWriter buffers a partial line until its end.
+type prefixWriter struct {
+	*multiplexer
+	name    string
+	pending []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+
+		if err := w.writeLine(w.name, w.pending[:i]); err != nil {
+			return 0, err
+		}
+
+		w.pending = w.pending[i+1:]
+	}
+}
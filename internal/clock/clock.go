@@ -0,0 +1,50 @@
+package clock
+
+import "time"
+
+// Clock tells the time and waits for it, so that the timing of a game can be driven by the tests.
+type Clock interface {
+	Now() time.Time
+	// After sends the time on the returned channel once the duration elapsed.
+	After(d time.Duration) <-chan time.Time
+	// NewTicker delivers ticks every d, it panics if d is not positive.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks at intervals, dropping them for a slow receiver like a time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// Real is the clock of the system.
+type Real struct{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+func (Real) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (Real) NewTicker(d time.Duration) Ticker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t *realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t *realTicker) Reset(d time.Duration) {
+	t.ticker.Reset(d)
+}
+
+func (t *realTicker) Stop() {
+	t.ticker.Stop()
+}
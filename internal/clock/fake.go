@@ -0,0 +1,143 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a clock that only moves when it is advanced, firing the timers and the ticks that fell due on the way.
+// It lets a test play a game of any length without waiting.
+type Fake struct {
+	lock   sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// fakeTimer is a pending After, or a ticker when it has a period.
+type fakeTimer struct {
+	at     time.Time
+	period time.Duration
+	c      chan time.Time
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.now
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	timer := &fakeTimer{at: f.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		timer.c <- f.now
+		return timer.c
+	}
+
+	f.timers = append(f.timers, timer)
+
+	return timer.c
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	timer := &fakeTimer{at: f.now.Add(d), period: d, c: make(chan time.Time, 1)}
+	f.timers = append(f.timers, timer)
+
+	return &fakeTicker{clock: f, timer: timer}
+}
+
+// Advance moves the clock forward, firing in order the timers and the ticks due until the new time.
+func (f *Fake) Advance(d time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	end := f.now.Add(d)
+	for {
+		next := -1
+		for i, timer := range f.timers {
+			if !timer.at.After(end) && (next < 0 || timer.at.Before(f.timers[next].at)) {
+				next = i
+			}
+		}
+
+		if next < 0 {
+			break
+		}
+
+		timer := f.timers[next]
+		f.now = timer.at
+
+		select {
+		case timer.c <- f.now:
+		default:
+		}
+
+		if timer.period > 0 {
+			timer.at = timer.at.Add(timer.period)
+		} else {
+			f.timers = append(f.timers[:next], f.timers[next+1:]...)
+		}
+	}
+
+	f.now = end
+}
+
+// Pending returns the number of timers and tickers waiting for the clock to advance.
+func (f *Fake) Pending() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return len(f.timers)
+}
+
+func (f *Fake) remove(timer *fakeTimer) {
+	for i, pending := range f.timers {
+		if pending == timer {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+type fakeTicker struct {
+	clock *Fake
+	timer *fakeTimer
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.timer.c
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	t.clock.remove(t.timer)
+	t.timer.at, t.timer.period = t.clock.now.Add(d), d
+	t.clock.timers = append(t.clock.timers, t.timer)
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	t.clock.remove(t.timer)
+}
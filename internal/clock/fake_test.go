@@ -0,0 +1,78 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeAfter(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFake(start)
+
+	late, early := clock.After(2*time.Second), clock.After(time.Second)
+
+	clock.Advance(1500 * time.Millisecond)
+
+	select {
+	case at := <-early:
+		if !at.Equal(start.Add(time.Second)) {
+			t.Fatalf("expected the timer to fire at 1s, got %s", at.Sub(start))
+		}
+	default:
+		t.Fatal("expected the 1s timer to fire")
+	}
+
+	select {
+	case <-late:
+		t.Fatal("unexpected 2s timer fired at 1.5s")
+	default:
+	}
+
+	clock.Advance(time.Second)
+
+	if _, ok := <-late; !ok || clock.Pending() != 0 || !clock.Now().Equal(start.Add(2500*time.Millisecond)) {
+		t.Fatalf("expected the 2s timer to fire and the clock at 2.5s, got %s", clock.Now().Sub(start))
+	}
+
+	if at := <-clock.After(0); !at.Equal(clock.Now()) {
+		t.Fatalf("expected an immediate timer, got %s", at)
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFake(start)
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Advance(time.Second)
+	if at := <-ticker.C(); !at.Equal(start.Add(time.Second)) {
+		t.Fatalf("expected a tick at 1s, got %s", at.Sub(start))
+	}
+
+	// Like a time.Ticker, the ticks a slow receiver missed are dropped.
+	clock.Advance(3 * time.Second)
+	if at := <-ticker.C(); !at.Equal(start.Add(2 * time.Second)) {
+		t.Fatalf("expected the first missed tick at 2s, got %s", at.Sub(start))
+	}
+
+	select {
+	case at := <-ticker.C():
+		t.Fatalf("unexpected tick at %s", at.Sub(start))
+	default:
+	}
+
+	ticker.Reset(500 * time.Millisecond)
+	clock.Advance(500 * time.Millisecond)
+	if at := <-ticker.C(); !at.Equal(start.Add(4500 * time.Millisecond)) {
+		t.Fatalf("expected a tick 500ms after the reset, got %s", at.Sub(start))
+	}
+
+	ticker.Stop()
+	clock.Advance(time.Minute)
+
+	select {
+	case at := <-ticker.C():
+		t.Fatalf("unexpected tick at %s after stop", at.Sub(start))
+	default:
+	}
+}
@@ -0,0 +1,65 @@
+// Package codec encodes the game events exchanged over Redis.
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/reactivejson/cowboys/internal/game"
+)
+
+// Names of the codecs, selected with CODEC.
+const (
+	NameJSON        = "json"
+	NameMessagePack = "msgpack"
+	NameProtobuf    = "protobuf"
+)
+
+// The binary codecs advertise themselves with the first byte of the payloads they encode, JSON payloads start
+// with the brace of the event object.
+const (
+	tagMessagePack byte = 'M'
+	tagProtobuf    byte = 'P'
+)
+
+// Codec encodes the events published over Redis. Whatever the codec a process publishes with, it decodes the
+// payloads of every codec, so that the processes of a game can switch codecs one at a time.
+type Codec interface {
+	Name() string
+	Marshal(event *game.Event) ([]byte, error)
+}
+
+// New returns the codec with the given name, JSON when the name is empty.
+func New(name string) (Codec, error) {
+	switch name {
+	case "", NameJSON:
+		return JSON{}, nil
+	case NameMessagePack:
+		return MessagePack{}, nil
+	case NameProtobuf:
+		return Protobuf{}, nil
+	default:
+		return nil, fmt.Errorf("unknown codec %q", name)
+	}
+}
+
+// Unmarshal decodes a payload of any codec, told apart by its first byte.
+func Unmarshal(payload []byte) (*game.Event, error) {
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("empty payload")
+	}
+
+	switch payload[0] {
+	case tagMessagePack:
+		return unmarshalMessagePack(payload[1:])
+	case tagProtobuf:
+		return unmarshalProtobuf(payload[1:])
+	default:
+		var event game.Event
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+
+		return &event, nil
+	}
+}
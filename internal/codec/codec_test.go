@@ -0,0 +1,199 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+)
+
+func cowboyAt(i int) *domain.Player {
+	return &domain.Player{
+		ID:     fmt.Sprintf("6f1c2a4e-0b7d-4c1e-9a3f-%012d", i),
+		Name:   fmt.Sprintf("cowboy-%d", i),
+		Health: 10 + i%7,
+		Damage: 1 + i%3,
+		Weapon: &domain.Weapon{Name: "revolver", Damage: 1 + i%3, Magazine: 6, ReloadTime: 2 * time.Second, Effects: domain.OnHits{
+			{Effect: domain.Effect{Kind: domain.EffectBleeding, Magnitude: 1, Rounds: 3}, Chance: 0.5},
+		}},
+		Ammo:      i % 6,
+		Speed:     1.5,
+		Shots:     1,
+		Position:  &domain.Position{X: i % 100, Y: i / 100},
+		Effects:   []*domain.Effect{{Kind: domain.EffectAim, Rounds: 2, Stacks: 1}},
+		MaxHealth: 16,
+		Heals:     2,
+		Allies:    []string{"ally"},
+	}
+}
+
+func roundEvent(t testing.TB, round *domain.Round) *game.Event {
+	event, err := game.NewEvent(game.EventRound, round)
+	if err != nil {
+		t.Fatalf("create round event: %v", err)
+	}
+
+	event.Game = "duel"
+
+	return event
+}
+
+// fullRound is a snapshot of the given number of cowboys.
+func fullRound(t testing.TB, cowboys int) *game.Event {
+	round := &domain.Round{Players: make(map[string]*domain.Player, cowboys), Number: 42, Grid: &domain.Grid{Width: 100, Height: 100}}
+	for i := 0; i < cowboys; i++ {
+		player := cowboyAt(i)
+		round.Players[player.ID] = player
+	}
+
+	return roundEvent(t, round)
+}
+
+// deltaRound is a delta where one cowboy out of a hundred changed and one died.
+func deltaRound(t testing.TB, cowboys int) *game.Event {
+	round := &domain.Round{Players: make(map[string]*domain.Player), Number: 43, Delta: true, Removed: []string{cowboyAt(0).ID}}
+	for i := 1; i < cowboys; i += 100 {
+		player := cowboyAt(i)
+		player.Health--
+		round.Players[player.ID] = player
+	}
+
+	return roundEvent(t, round)
+}
+
+func TestCodecs(t *testing.T) {
+	events := []*game.Event{fullRound(t, 3), deltaRound(t, 300), {Type: game.Heartbeat, Game: "duel"}}
+	for eventType, data := range map[game.EventType]interface{}{
+		game.Registration:    cowboyAt(1),
+		game.EventReady:      &domain.Ready{ID: "bill"},
+		game.EventAction:     &domain.Action{Src: "bill", Kind: domain.ActionMove, Move: &domain.Position{X: 1}, Round: 3},
+		game.EventShot:       &domain.Action{Src: "bill", Dest: "jesse"},
+		game.EventReload:     &domain.Reload{ID: "bill"},
+		game.EventKill:       &domain.Kill{Shooter: "Bill", Target: "Jesse"},
+		game.EventGameOver:   &domain.Result{Winner: cowboyAt(1), Standings: []*domain.Player{cowboyAt(1), cowboyAt(2)}},
+		game.EventMessage:    &domain.Message{From: "bill", Text: "draw!"},
+		game.EventAlliance:   &domain.Alliance{From: "bill", To: "jesse", Kind: domain.AlliancePropose},
+		game.EventBetrayal:   &domain.Betrayal{Traitor: "Bill", Victim: "Jesse"},
+		game.EventResync:     &domain.Resync{ID: "bill", Last: 7},
+		game.EventReadyCheck: nil,
+		"custom":             map[string]interface{}{"newer": []int{1, 2}},
+	} {
+		event, _ := game.NewEvent(eventType, data)
+		events = append(events, event)
+	}
+
+	for _, name := range []string{NameJSON, NameMessagePack, NameProtobuf} {
+		codec, err := New(name)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+
+		for _, event := range events {
+			payload, err := codec.Marshal(event)
+			if err != nil {
+				t.Fatalf("%s: marshal %s: %v", name, event.Type, err)
+			}
+
+			decoded, err := Unmarshal(payload)
+			if err != nil {
+				t.Fatalf("%s: unmarshal %s: %v", name, event.Type, err)
+			}
+
+			if decoded.Type != event.Type || decoded.Game != event.Game || !sameData(t, decoded.Data, event.Data) {
+				t.Errorf("%s: expected %s %s, got %s %s", name, event.Type, event.Data, decoded.Type, decoded.Data)
+			}
+		}
+	}
+}
+
+func TestCodecCountdown(t *testing.T) {
+	countdown := &domain.Countdown{StartAt: time.Now().Add(3 * time.Second), ServerTime: time.Now()}
+	event, _ := game.NewEvent(game.EventCountdown, countdown)
+
+	for _, name := range []string{NameJSON, NameMessagePack, NameProtobuf} {
+		codec, _ := New(name)
+		payload, err := codec.Marshal(event)
+		if err != nil {
+			t.Fatalf("%s: unexpected marshal err: %v", name, err)
+		}
+
+		decoded, err := Unmarshal(payload)
+		if err != nil {
+			t.Fatalf("%s: unexpected unmarshal err: %v", name, err)
+		}
+
+		var got domain.Countdown
+		if err := json.Unmarshal(decoded.Data, &got); err != nil || !got.StartAt.Equal(countdown.StartAt) || !got.ServerTime.Equal(countdown.ServerTime) {
+			t.Errorf("%s: expected %+v, got %+v (%v)", name, countdown, got, err)
+		}
+	}
+}
+
+func TestCodecUnknown(t *testing.T) {
+	if _, err := New("xml"); err == nil {
+		t.Fatal("expected an unknown codec to be rejected")
+	}
+
+	for _, payload := range []string{"", "{\"type\":", "Mgarbage", "P\xff\xff"} {
+		if _, err := Unmarshal([]byte(payload)); err == nil {
+			t.Errorf("%q: expected an error", payload)
+		}
+	}
+}
+
+// sameData compares two JSON values whatever the order of their keys.
+func sameData(t *testing.T, a, b json.RawMessage) bool {
+	t.Helper()
+
+	if len(a) == 0 || len(b) == 0 {
+		return (len(a) == 0 || string(a) == "null") && (len(b) == 0 || string(b) == "null")
+	}
+
+	var x, y interface{}
+	if err := json.Unmarshal(a, &x); err != nil {
+		t.Fatalf("unmarshal %s: %v", a, err)
+	}
+
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatalf("unmarshal %s: %v", b, err)
+	}
+
+	return reflect.DeepEqual(x, y)
+}
+
+// BenchmarkCodecs encodes and decodes rounds of 1k and 10k cowboys, reporting the size of the payloads.
+func BenchmarkCodecs(b *testing.B) {
+	for _, cowboys := range []int{1000, 10000} {
+		for _, round := range []struct {
+			kind  string
+			event *game.Event
+		}{{"full", fullRound(b, cowboys)}, {"delta", deltaRound(b, cowboys)}} {
+			event := round.event
+			for _, name := range []string{NameJSON, NameMessagePack, NameProtobuf} {
+				codec, _ := New(name)
+
+				b.Run(fmt.Sprintf("%s/%s/%d", name, round.kind, cowboys), func(b *testing.B) {
+					var size int
+					for i := 0; i < b.N; i++ {
+						payload, err := codec.Marshal(event)
+						if err != nil {
+							b.Fatalf("unexpected marshal err: %v", err)
+						}
+
+						if _, err := Unmarshal(payload); err != nil {
+							b.Fatalf("unexpected unmarshal err: %v", err)
+						}
+
+						size = len(payload)
+					}
+
+					b.ReportMetric(float64(size), "bytes/event")
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package codec
+
+import (
+	"encoding/json"
+
+	"github.com/reactivejson/cowboys/internal/game"
+)
+
+// JSON encodes the events as JSON objects, the format every version of the cowboys understands.
+type JSON struct{}
+
+func (JSON) Name() string {
+	return NameJSON
+}
+
+func (JSON) Marshal(event *game.Event) ([]byte, error) {
+	return json.Marshal(event)
+}
@@ -0,0 +1,128 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MessagePack encodes the events as MessagePack maps. The data of the known event types is encoded from its
+// domain type, the keys being the JSON names, and the data of the others as generic JSON values.
+type MessagePack struct{}
+
+// packedEvent is the MessagePack form of an event, with short keys.
+type packedEvent struct {
+	Type game.EventType     `msgpack:"t"`
+	Data msgpack.RawMessage `msgpack:"d,omitempty"`
+	Game string             `msgpack:"g,omitempty"`
+}
+
+func (MessagePack) Name() string {
+	return NameMessagePack
+}
+
+func (MessagePack) Marshal(event *game.Event) ([]byte, error) {
+	packed := packedEvent{Type: event.Type, Game: event.Game}
+
+	if len(event.Data) > 0 {
+		data := dataOf(event.Type)
+		if err := json.Unmarshal(event.Data, data); err != nil {
+			return nil, fmt.Errorf("unmarshal %s event data: %w", event.Type, err)
+		}
+
+		var err error
+		if packed.Data, err = pack(data); err != nil {
+			return nil, fmt.Errorf("pack %s event data: %w", event.Type, err)
+		}
+	}
+
+	payload, err := pack(&packed)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{tagMessagePack}, payload...), nil
+}
+
+func unmarshalMessagePack(payload []byte) (*game.Event, error) {
+	var packed packedEvent
+	if err := unpack(payload, &packed); err != nil {
+		return nil, fmt.Errorf("unpack event: %w", err)
+	}
+
+	event := &game.Event{Type: packed.Type, Game: packed.Game}
+	if len(packed.Data) == 0 {
+		return event, nil
+	}
+
+	data := dataOf(packed.Type)
+	if err := unpack(packed.Data, data); err != nil {
+		return nil, fmt.Errorf("unpack %s event data: %w", packed.Type, err)
+	}
+
+	var err error
+	if event.Data, err = json.Marshal(data); err != nil {
+		return nil, fmt.Errorf("marshal %s event data: %w", packed.Type, err)
+	}
+
+	return event, nil
+}
+
+// pack encodes a value with the JSON names of its fields and the smallest integer types.
+func pack(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := msgpack.NewEncoder(&buf)
+	encoder.SetCustomStructTag("json")
+	encoder.UseCompactInts(true)
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func unpack(payload []byte, value interface{}) error {
+	decoder := msgpack.NewDecoder(bytes.NewReader(payload))
+	decoder.SetCustomStructTag("json")
+
+	return decoder.Decode(value)
+}
+
+// dataOf returns a pointer to the data type of an event type, a generic JSON value for the unknown ones.
+func dataOf(eventType game.EventType) interface{} {
+	switch eventType {
+	case game.Registration:
+		return &domain.Player{}
+	case game.EventReady:
+		return &domain.Ready{}
+	case game.EventCountdown:
+		return &domain.Countdown{}
+	case game.EventRound:
+		return &domain.Round{}
+	case game.EventShot, game.EventAction:
+		return &domain.Action{}
+	case game.EventReload:
+		return &domain.Reload{}
+	case game.EventKill:
+		return &domain.Kill{}
+	case game.EventGameOver:
+		return &domain.Result{}
+	case game.EventMessage:
+		return &domain.Message{}
+	case game.EventAlliance:
+		return &domain.Alliance{}
+	case game.EventBetrayal:
+		return &domain.Betrayal{}
+	case game.EventResync:
+		return &domain.Resync{}
+	default:
+		var value interface{}
+		return &value
+	}
+}
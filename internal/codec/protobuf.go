@@ -0,0 +1,26 @@
+package codec
+
+import (
+	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/grpcapi"
+)
+
+// Protobuf encodes the events as the GameEvent envelopes of the gRPC contract, with their typed payloads.
+type Protobuf struct{}
+
+func (Protobuf) Name() string {
+	return NameProtobuf
+}
+
+func (Protobuf) Marshal(event *game.Event) ([]byte, error) {
+	payload, err := grpcapi.MarshalEvent(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{tagProtobuf}, payload...), nil
+}
+
+func unmarshalProtobuf(payload []byte) (*game.Event, error) {
+	return grpcapi.UnmarshalEvent(payload)
+}
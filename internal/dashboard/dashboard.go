@@ -0,0 +1,72 @@
+package dashboard
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+)
+
+const eventsPath = "/events"
+
+//go:embed static
+var static embed.FS
+
+// Handler serves the arena view and the live event stream it is fed by.
+func Handler(hub *Hub, logger *log.Logger) http.Handler {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(fmt.Sprintf("embedded dashboard assets: %v", err))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.FS(assets)))
+	mux.HandleFunc(eventsPath, func(w http.ResponseWriter, r *http.Request) {
+		stream(hub, logger, w, r)
+	})
+
+	return mux
+}
+
+// stream writes the game events as server-sent events until the viewer leaves or the hub drops it.
+func stream(hub *Hub, logger *log.Logger, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			payload, err := json.Marshal(event)
+			if err != nil {
+				logger.Printf("marshal dashboard event: %v", err)
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", payload); err != nil {
+				return
+			}
+
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package dashboard
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/game"
+)
+
+func TestHubReplay(t *testing.T) {
+	hub := NewHub()
+
+	registration, _ := game.NewEvent(game.Registration, nil)
+	firstRound, _ := game.NewEvent(game.EventRound, nil)
+	kill, _ := game.NewEvent(game.EventKill, nil)
+	secondRound, _ := game.NewEvent(game.EventRound, nil)
+
+	for _, event := range []*game.Event{registration, firstRound, kill, secondRound} {
+		hub.Publish(event)
+	}
+
+	events, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+
+	for _, want := range []*game.Event{registration, kill, secondRound} {
+		if got := <-events; got != want {
+			t.Fatalf("expected replayed %q event, got %q", want.Type, got.Type)
+		}
+	}
+
+	gameOver, _ := game.NewEvent(game.EventGameOver, nil)
+	hub.Publish(gameOver)
+
+	if got := <-events; got != gameOver {
+		t.Fatalf("expected live %q event, got %q", gameOver.Type, got.Type)
+	}
+
+	hub.Close()
+
+	if _, ok := <-events; ok {
+		t.Fatalf("expected the subscription to be closed with the hub")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	hub := NewHub()
+	server := httptest.NewServer(Handler(hub, log.New(io.Discard, "", 0)))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/")
+	if err != nil {
+		t.Fatalf("get arena view: %v", err)
+	}
+
+	page, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(page), "Cowboys arena") {
+		t.Fatalf("unexpected arena view %d: %s", resp.StatusCode, page)
+	}
+
+	kill, _ := game.NewEvent(game.EventKill, map[string]string{"shooter": "p1", "target": "p2"})
+	hub.Publish(kill)
+
+	resp, err = http.Get(server.URL + eventsPath)
+	if err != nil {
+		t.Fatalf("get event stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", contentType)
+	}
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatalf("read event stream: %v", err)
+	}
+
+	var event game.Event
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+		t.Fatalf("unmarshal streamed event %q: %v", line, err)
+	}
+
+	if event.Type != game.EventKill {
+		t.Fatalf("expected streamed %q event, got %q", game.EventKill, event.Type)
+	}
+
+	hub.Close()
+}
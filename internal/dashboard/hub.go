@@ -0,0 +1,111 @@
+package dashboard
+
+import (
+	"sync"
+
+	"github.com/reactivejson/cowboys/internal/game"
+)
+
+// subscriberBuffer is the number of events a slow viewer may lag behind before it is disconnected.
+const subscriberBuffer = 64
+
+// Hub fans the game events out to the connected viewers. A viewer joining in the middle of a game
+// is replayed the history: registrations, kills and the result, followed by the latest phase event.
+type Hub struct {
+	lock        sync.Mutex
+	history     []*game.Event
+	last        *game.Event
+	subscribers map[chan *game.Event]struct{}
+	closed      bool
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[chan *game.Event]struct{}),
+	}
+}
+
+// Publish sends an event to every viewer without blocking.
+func (h *Hub) Publish(event *game.Event) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	if isPhase(event.Type) {
+		h.last = event
+	} else {
+		h.history = append(h.history, event)
+	}
+
+	for subscriber := range h.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			// The viewer does not keep up, it reconnects and is replayed the history.
+			h.drop(subscriber)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the history then the live events.
+// The channel is closed when the viewer is dropped or the hub is closed.
+func (h *Hub) Subscribe() (<-chan *game.Event, func()) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	subscriber := make(chan *game.Event, len(h.history)+subscriberBuffer)
+	for _, event := range h.history {
+		subscriber <- event
+	}
+
+	if h.last != nil {
+		subscriber <- h.last
+	}
+
+	if h.closed {
+		close(subscriber)
+		return subscriber, func() {}
+	}
+
+	h.subscribers[subscriber] = struct{}{}
+
+	return subscriber, func() {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+
+		h.drop(subscriber)
+	}
+}
+
+// Close disconnects every viewer.
+func (h *Hub) Close() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.closed = true
+	for subscriber := range h.subscribers {
+		h.drop(subscriber)
+	}
+}
+
+func (h *Hub) drop(subscriber chan *game.Event) {
+	if _, ok := h.subscribers[subscriber]; !ok {
+		return
+	}
+
+	delete(h.subscribers, subscriber)
+	close(subscriber)
+}
+
+// isPhase reports whether the event describes the whole game state, so that only the latest one matters.
+func isPhase(eventType game.EventType) bool {
+	switch eventType {
+	case game.Heartbeat, game.EventReadyCheck, game.EventCountdown, game.EventRound:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,152 @@
+(function () {
+  'use strict';
+
+  const cowboys = new Map();
+  const phase = document.getElementById('phase');
+  const board = document.getElementById('cowboys');
+  const feed = document.getElementById('feed');
+
+  function card(player) {
+    let cowboy = cowboys.get(player.id);
+    if (!cowboy) {
+      const element = document.createElement('div');
+      element.className = 'card';
+      element.innerHTML = '<h3></h3><div class="bar"><div></div></div>' +
+        '<div class="stats"><span class="health"></span><span class="damage"></span><span class="effects"></span></div>';
+      element.querySelector('h3').textContent = player.name;
+      board.appendChild(element);
+
+      cowboy = {element: element, name: player.name, maxHealth: player.health};
+      cowboys.set(player.id, cowboy);
+    }
+
+    return cowboy;
+  }
+
+  function update(player) {
+    const cowboy = card(player);
+    const health = Math.max(player.health, 0);
+    const ratio = Math.min(100, Math.round(100 * health / Math.max(cowboy.maxHealth, 1)));
+    const bar = cowboy.element.querySelector('.bar div');
+
+    bar.style.width = ratio + '%';
+    bar.classList.toggle('low', ratio < 30);
+    cowboy.element.querySelector('.health').textContent = health + ' hp';
+    cowboy.element.querySelector('.damage').textContent = player.damage + ' dmg';
+    cowboy.element.querySelector('.effects').textContent = (player.effects || []).map(function (effect) {
+      return effect.kind + (effect.stacks > 1 ? ' x' + effect.stacks : '');
+    }).join(', ');
+  }
+
+  function name(id) {
+    const cowboy = cowboys.get(id);
+    return cowboy ? cowboy.name : id;
+  }
+
+  function setPhase(text, over) {
+    phase.textContent = text;
+    phase.classList.toggle('over', !!over);
+  }
+
+  const handlers = {
+    registration: function (player) {
+      update(player);
+    },
+    heartbeat: function () {
+      setPhase('Waiting for cowboys (' + cowboys.size + ' joined)');
+    },
+    ready_check: function () {
+      setPhase('Ready check');
+    },
+    countdown: function (countdown) {
+      const left = (new Date(countdown.start_at) - new Date(countdown.server_time)) / 1000;
+      setPhase('Draw in ' + Math.ceil(left) + 's');
+    },
+    round: function (round) {
+      const alive = round.Players || {};
+      Object.values(alive).forEach(update);
+      cowboys.forEach(function (cowboy, id) {
+        cowboy.element.classList.toggle('dead', !(id in alive));
+      });
+
+      let text = 'Round ' + (round.number || '');
+      if (round.damage_multiplier) {
+        text += ' - sudden death x' + round.damage_multiplier;
+      }
+      setPhase(text);
+    },
+    kill: function (kill) {
+      const item = document.createElement('li');
+      item.textContent = kill.shooter ? kill.shooter + ' shot ' + kill.target : kill.target + ' is out';
+      feed.insertBefore(item, feed.firstChild);
+    },
+    effect: function (affliction) {
+      const item = document.createElement('li');
+      item.className = 'effect';
+      item.textContent = affliction.target + ' is under ' + affliction.effect.kind +
+        (affliction.effect.source ? ' from ' + affliction.effect.source : '');
+      feed.insertBefore(item, feed.firstChild);
+    },
+    message: function (message) {
+      const item = document.createElement('li');
+      item.className = 'message';
+      const to = cowboys.get(message.to);
+      item.textContent = name(message.from) + (to ? ' to ' + to.name : '') + ': ' + message.text;
+      feed.insertBefore(item, feed.firstChild);
+    },
+    alliance: function (alliance) {
+      const verbs = {propose: ' proposes an alliance to ', accept: ' allies with ', break: ' breaks the alliance with '};
+      const item = document.createElement('li');
+      item.className = 'alliance';
+      item.textContent = name(alliance.from) + (verbs[alliance.kind] || ' ' + alliance.kind + ' ') + name(alliance.to);
+      feed.insertBefore(item, feed.firstChild);
+    },
+    betrayal: function (betrayal) {
+      const item = document.createElement('li');
+      item.className = 'betrayal';
+      item.textContent = betrayal.traitor + ' betrays ' + betrayal.victim;
+      feed.insertBefore(item, feed.firstChild);
+    },
+    admin: function (action) {
+      const item = document.createElement('li');
+      item.className = 'admin';
+      const target = cowboys.get(action.target);
+      item.textContent = 'Operator: ' + action.command + (target ? ' ' + target.name : '');
+      feed.insertBefore(item, feed.firstChild);
+    },
+    game_over: function (result) {
+      if (result.winner) {
+        setPhase(result.winner.name + ' wins!', true);
+      } else if (result.draw) {
+        setPhase('Draw, nobody is standing', true);
+      } else {
+        setPhase('Aborted: ' + result.reason, true);
+      }
+
+      const ranking = document.getElementById('ranking');
+      ranking.innerHTML = '';
+      (result.standings || []).forEach(function (player) {
+        const item = document.createElement('li');
+        item.textContent = player.name + ' (' + Math.max(player.health, 0) + ' hp)';
+        ranking.appendChild(item);
+      });
+      document.getElementById('standings').hidden = false;
+
+      source.close();
+    }
+  };
+
+  const source = new EventSource('events');
+  source.onmessage = function (message) {
+    const event = JSON.parse(message.data);
+    const handler = handlers[event.type];
+    if (handler) {
+      handler(event.data);
+    }
+  };
+  source.onerror = function () {
+    if (!phase.classList.contains('over')) {
+      setPhase('Connection lost, retrying...');
+    }
+  };
+}());
@@ -0,0 +1,77 @@
+package domain
+
+import "fmt"
+
+// ActionKind is the kind of action a cowboy takes in a round.
+type ActionKind string
+
+const (
+	// ActionShoot fires at the target of the action.
+	ActionShoot ActionKind = "shoot"
+	// ActionMove steps to an adjacent tile in a positional game.
+	ActionMove ActionKind = "move"
+	// ActionDodge lowers the chance of the cowboy to be hit until the next round.
+	ActionDodge ActionKind = "dodge"
+	// ActionAim makes the next shot of the cowboy stronger.
+	ActionAim ActionKind = "aim"
+	// ActionHeal restores some health of the cowboy, a limited number of times.
+	ActionHeal ActionKind = "heal"
+	// ActionCover halves the damage the cowboy takes until the next round.
+	ActionCover ActionKind = "cover"
+)
+
+const (
+	// DodgeHitChance multiplies the chance to hit a dodging cowboy.
+	DodgeHitChance = 0.5
+	// AimMultiplier multiplies the damage of an aimed shot.
+	AimMultiplier = 2
+	// AimRounds is how many round openings an aim lasts: the shot must be fired in the next round.
+	AimRounds = 2
+)
+
+// Action is what a cowboy does in a round: it shoots at Dest, steps to the adjacent tile Move in a positional game,
+// or takes one of the other actions of ActionKind.
+type Action struct {
+	Src  string `json:"from"`
+	Dest string `json:"to,omitempty"`
+	// Round is the number of the round the action answers to, used in lockstep mode.
+	Round int       `json:"round,omitempty"`
+	Move  *Position `json:"move,omitempty"`
+	// Kind is the action, a shot or a move depending on Move when empty.
+	Kind ActionKind `json:"kind,omitempty"`
+}
+
+// Type returns the kind of the action.
+func (a *Action) Type() ActionKind {
+	switch {
+	case a.Kind != "":
+		return a.Kind
+	case a.Move != nil:
+		return ActionMove
+	default:
+		return ActionShoot
+	}
+}
+
+// Validate checks that the action carries what its kind needs: a target for a shot, a tile for a move,
+// and neither for the others.
+func (a *Action) Validate() error {
+	switch kind := a.Type(); kind {
+	case ActionShoot:
+		if a.Dest == "" || a.Move != nil {
+			return fmt.Errorf("a shot needs a target and no tile")
+		}
+	case ActionMove:
+		if a.Move == nil || a.Dest != "" {
+			return fmt.Errorf("a move needs a tile and no target")
+		}
+	case ActionDodge, ActionAim, ActionHeal, ActionCover:
+		if a.Move != nil || a.Dest != "" {
+			return fmt.Errorf("%s needs neither a target nor a tile", kind)
+		}
+	default:
+		return fmt.Errorf("unknown action %q", kind)
+	}
+
+	return nil
+}
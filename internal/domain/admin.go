@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// AdminCommand is an operator action on a running game.
+type AdminCommand string
+
+const (
+	// AdminPause stops the rounds until AdminResume.
+	AdminPause  AdminCommand = "pause"
+	AdminResume AdminCommand = "resume"
+	// AdminTick changes the interval between two rounds.
+	AdminTick AdminCommand = "tick"
+	// AdminKick removes a cowboy from the game.
+	AdminKick AdminCommand = "kick"
+	// AdminAdjust sets the health or the damage of a cowboy.
+	AdminAdjust AdminCommand = "adjust"
+	// AdminEnd ends the game with a declared winner.
+	AdminEnd AdminCommand = "end"
+	// AdminEffect puts a cowboy under a status effect.
+	AdminEffect AdminCommand = "effect"
+)
+
+// AdminAction records an operator action. Target is the cowboy the action applies to, or the declared winner.
+type AdminAction struct {
+	Command  AdminCommand  `json:"command"`
+	Target   string        `json:"target,omitempty"`
+	Health   *int          `json:"health,omitempty"`
+	Damage   *int          `json:"damage,omitempty"`
+	Interval time.Duration `json:"interval,omitempty"`
+	Effect   *Effect       `json:"effect,omitempty"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+// MaxMessageLength bounds the text of a message.
+const MaxMessageLength = 280
+
+// Message is a direct message of a cowboy, routed through the master so that it is recorded.
+// An empty To sends it to every cowboy.
+type Message struct {
+	From string `json:"from"`
+	To   string `json:"to,omitempty"`
+	Text string `json:"text"`
+}
+
+// AllianceKind is a diplomatic step between two cowboys.
+type AllianceKind string
+
+const (
+	// AlliancePropose offers an alliance.
+	AlliancePropose AllianceKind = "propose"
+	// AllianceAccept accepts the alliance the other cowboy proposed.
+	AllianceAccept AllianceKind = "accept"
+	// AllianceBreak ends an alliance.
+	AllianceBreak AllianceKind = "break"
+)
+
+// Alliance is a diplomatic step of the cowboy From towards the cowboy To.
+type Alliance struct {
+	From string       `json:"from"`
+	To   string       `json:"to"`
+	Kind AllianceKind `json:"kind"`
+}
+
+// Betrayal records a cowboy shooting at an ally, which ends their alliance.
+type Betrayal struct {
+	Traitor string `json:"traitor"`
+	Victim  string `json:"victim"`
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EffectKind is a status effect a cowboy can suffer or enjoy.
+type EffectKind string
+
+const (
+	// EffectBleeding deals its magnitude in damage every round.
+	EffectBleeding EffectKind = "bleeding"
+	// EffectStun skips the next shot of the cowboy.
+	EffectStun EffectKind = "stun"
+	// EffectDamageBuff adds its magnitude to the damage of the shots of the cowboy.
+	EffectDamageBuff EffectKind = "damage_buff"
+	// EffectDefenseBuff removes its magnitude from the damage of the shots the cowboy takes.
+	EffectDefenseBuff EffectKind = "defense_buff"
+
+	// The stances taken with an action, see ActionKind.
+	EffectDodge EffectKind = "dodge"
+	EffectAim   EffectKind = "aim"
+	EffectCover EffectKind = "cover"
+)
+
+// Stacking decides what happens when an effect is applied to a cowboy already suffering it.
+type Stacking string
+
+const (
+	// StackRefresh restarts the duration of the effect.
+	StackRefresh Stacking = "refresh"
+	// StackIntensity adds a stack, multiplying the magnitude, and restarts the duration.
+	StackIntensity Stacking = "intensity"
+	// StackIgnore keeps the effect as it is.
+	StackIgnore Stacking = "ignore"
+)
+
+// MaxStacks bounds the stacks of an effect stacking in intensity.
+const MaxStacks = 5
+
+// Effect is a status effect. Rounds is the number of round openings it lasts: bleeding deals its damage
+// at each of them. Applied effects have Stacks and Source, the name of the cowboy who applied it.
+type Effect struct {
+	Kind      EffectKind `json:"kind"`
+	Magnitude int        `json:"magnitude,omitempty"`
+	Rounds    int        `json:"rounds"`
+	// Stacking defaults to intensity for bleeding, ignore for stun and refresh for the buffs.
+	Stacking Stacking `json:"stacking,omitempty"`
+	Stacks   int      `json:"stacks,omitempty"`
+	Source   string   `json:"source,omitempty"`
+}
+
+// Validate checks the effect can be applied.
+func (e *Effect) Validate() error {
+	switch e.Kind {
+	case EffectBleeding, EffectDamageBuff, EffectDefenseBuff:
+		if e.Magnitude < 1 {
+			return fmt.Errorf("%s needs a positive magnitude", e.Kind)
+		}
+	case EffectStun, EffectDodge, EffectAim, EffectCover:
+	default:
+		return fmt.Errorf("unknown effect %q", e.Kind)
+	}
+
+	switch {
+	case e.Rounds < 1:
+		return fmt.Errorf("%s needs a positive number of rounds", e.Kind)
+	case e.Stacking != "" && e.Stacking != StackRefresh && e.Stacking != StackIntensity && e.Stacking != StackIgnore:
+		return fmt.Errorf("unknown stacking %q", e.Stacking)
+	default:
+		return nil
+	}
+}
+
+// Stack returns the stacking rule of the effect.
+func (e *Effect) Stack() Stacking {
+	switch {
+	case e.Stacking != "":
+		return e.Stacking
+	case e.Kind == EffectBleeding:
+		return StackIntensity
+	case e.Kind == EffectStun:
+		return StackIgnore
+	default:
+		return StackRefresh
+	}
+}
+
+// Strength is the magnitude of the effect multiplied by its stacks.
+func (e *Effect) Strength() int {
+	if e.Stacks < 1 {
+		return e.Magnitude
+	}
+
+	return e.Magnitude * e.Stacks
+}
+
+// OnHit is an effect a weapon applies when its shot hits, to the target or to the shooter itself.
+type OnHit struct {
+	Effect
+	// Chance is the probability to apply the effect, 1 when omitted.
+	Chance float64 `json:"chance,omitempty"`
+	Self   bool    `json:"self,omitempty"`
+}
+
+// OnHits are the effects of a weapon, configured as written for ParseOnHits.
+type OnHits []*OnHit
+
+// Decode parses the effects of a weapon from the environment.
+func (o *OnHits) Decode(value string) error {
+	effects, err := ParseOnHits(value)
+	if err != nil {
+		return err
+	}
+
+	*o = effects
+
+	return nil
+}
+
+// ParseOnHits parses comma separated effects written kind:magnitude:rounds[:chance[:self]],
+// e.g. bleeding:1:3:0.5,damage_buff:1:2:1:self.
+func ParseOnHits(value string) (OnHits, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var effects OnHits
+	for _, spec := range strings.Split(value, ",") {
+		fields := strings.Split(strings.TrimSpace(spec), ":")
+		if len(fields) < 3 || len(fields) > 5 {
+			return nil, fmt.Errorf("invalid effect %q, expected kind:magnitude:rounds[:chance[:self]]", spec)
+		}
+
+		effect := OnHit{Effect: Effect{Kind: EffectKind(fields[0])}}
+
+		var err error
+		if fields[1] != "" {
+			if effect.Magnitude, err = strconv.Atoi(fields[1]); err != nil {
+				return nil, fmt.Errorf("invalid magnitude of effect %q", spec)
+			}
+		}
+
+		if effect.Rounds, err = strconv.Atoi(fields[2]); err != nil {
+			return nil, fmt.Errorf("invalid rounds of effect %q", spec)
+		}
+
+		if len(fields) > 3 {
+			if effect.Chance, err = strconv.ParseFloat(fields[3], 64); err != nil {
+				return nil, fmt.Errorf("invalid chance of effect %q", spec)
+			}
+		}
+
+		if len(fields) > 4 {
+			if fields[4] != "self" {
+				return nil, fmt.Errorf("invalid target of effect %q, expected self", spec)
+			}
+
+			effect.Self = true
+		}
+
+		if err := effect.Validate(); err != nil {
+			return nil, err
+		}
+
+		effects = append(effects, &effect)
+	}
+
+	return effects, nil
+}
+
+// Validate checks the effect can be applied and its chance is a probability.
+func (o *OnHit) Validate() error {
+	if o.Chance < 0 || o.Chance > 1 {
+		return fmt.Errorf("chance of %s must be between 0 and 1", o.Kind)
+	}
+
+	return o.Effect.Validate()
+}
+
+// Affliction records an effect applied to a cowboy.
+type Affliction struct {
+	Target string  `json:"target"`
+	Effect *Effect `json:"effect"`
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Hit chances of a shot in a positional game.
+const (
+	// HitFalloff is the hit chance lost per tile of distance beyond point blank.
+	HitFalloff = 0.1
+	// MinHitChance is the hit chance of a shot across the arena.
+	MinHitChance = 0.1
+)
+
+// Position is a tile of the grid of a positional game.
+type Position struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.X, p.Y)
+}
+
+// Distance is the euclidean distance to another tile.
+func (p Position) Distance(other Position) float64 {
+	return math.Hypot(float64(p.X-other.X), float64(p.Y-other.Y))
+}
+
+// Adjacent reports whether other is one step away, diagonals included.
+func (p Position) Adjacent(other Position) bool {
+	dx, dy := p.X-other.X, p.Y-other.Y
+
+	return p != other && dx >= -1 && dx <= 1 && dy >= -1 && dy <= 1
+}
+
+// ParsePosition parses a tile written x:y.
+func ParsePosition(value string) (Position, error) {
+	x, y, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return Position{}, fmt.Errorf("invalid tile %q, expected x:y", value)
+	}
+
+	px, errX := strconv.Atoi(x)
+	py, errY := strconv.Atoi(y)
+	if errX != nil || errY != nil {
+		return Position{}, fmt.Errorf("invalid tile %q, expected x:y", value)
+	}
+
+	return Position{X: px, Y: py}, nil
+}
+
+// Grid is the battlefield of a positional game. Cowboys standing on a cover tile are harder to hit.
+type Grid struct {
+	Width  int        `json:"width"`
+	Height int        `json:"height"`
+	Cover  []Position `json:"cover,omitempty"`
+}
+
+// ParseGrid parses a grid size written WIDTHxHEIGHT and its cover tiles. An empty size is no grid.
+func ParseGrid(size string, cover []string) (*Grid, error) {
+	if size == "" {
+		return nil, nil
+	}
+
+	width, height, ok := strings.Cut(size, "x")
+	if !ok {
+		return nil, fmt.Errorf("invalid arena size %q, expected WIDTHxHEIGHT", size)
+	}
+
+	grid := Grid{}
+
+	var errW, errH error
+	grid.Width, errW = strconv.Atoi(width)
+	grid.Height, errH = strconv.Atoi(height)
+	if errW != nil || errH != nil || grid.Width < 1 || grid.Height < 1 {
+		return nil, fmt.Errorf("invalid arena size %q, expected WIDTHxHEIGHT", size)
+	}
+
+	for _, value := range cover {
+		tile, err := ParsePosition(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if !grid.Contains(tile) {
+			return nil, fmt.Errorf("cover %s is out of the arena", tile)
+		}
+
+		grid.Cover = append(grid.Cover, tile)
+	}
+
+	return &grid, nil
+}
+
+// Contains reports whether the tile is on the grid.
+func (g *Grid) Contains(p Position) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.Width && p.Y < g.Height
+}
+
+// IsCover reports whether the tile is a cover tile.
+func (g *Grid) IsCover(p Position) bool {
+	for _, cover := range g.Cover {
+		if cover == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HitChance is the probability that a shot from a tile hits a cowboy standing on another one:
+// certain point blank, falling by HitFalloff per tile of distance down to MinHitChance, and halved
+// when the target stands on cover.
+func (g *Grid) HitChance(from, to Position) float64 {
+	chance := 1 - HitFalloff*math.Max(0, from.Distance(to)-1)
+	if chance < MinHitChance {
+		chance = MinHitChance
+	}
+
+	if g.IsCover(to) {
+		chance /= 2
+	}
+
+	return chance
+}
@@ -1,7 +1,64 @@
 package domain
 
+import "time"
+
 type MasterConfig struct {
-	Port      string `envconfig:"PORT"               required:"false" default:":8080"`
-	RedisAddr string `envconfig:"REDIS_ADDR"               required:"false" default:"redis:6379"`
-	Players   int    `envconfig:"COMPETITORS"`
+	RedisConfig
+	OutboxConfig
+	SubscriptionConfig
+
+	Port        string        `envconfig:"PORT"               required:"false" default:":8080"`
+	GRPCPort    string        `envconfig:"GRPC_PORT"          required:"false"`
+	Players     int           `envconfig:"COMPETITORS"`
+	ReadyCheck  bool          `envconfig:"READY_CHECK"        required:"false" default:"true"`
+	Countdown   time.Duration `envconfig:"COUNTDOWN"          required:"false" default:"3s"`
+	Lockstep    bool          `envconfig:"LOCKSTEP"           required:"false" default:"false"`
+	StallRounds int           `envconfig:"STALL_ROUNDS"       required:"false" default:"3"`
+	StallPolicy StallPolicy   `envconfig:"STALL_POLICY"       required:"false" default:"abort"`
+	Chaos       string        `envconfig:"CHAOS"              required:"false"`
+	Tick        time.Duration `envconfig:"TICK"               required:"false" default:"1s"`
+	AdminToken  string        `envconfig:"ADMIN_TOKEN"        required:"false"`
+	ResultFile  string        `envconfig:"RESULT_FILE"        required:"false"`
+	ArenaSize   string        `envconfig:"ARENA_SIZE"         required:"false"`
+	ArenaCover  []string      `envconfig:"ARENA_COVER"        required:"false"`
+	Seed        int64         `envconfig:"SEED"               required:"false" default:"0"`
+	HealPoints  int           `envconfig:"HEAL_POINTS"        required:"false" default:"3"`
+	Heals       int           `envconfig:"HEALS"              required:"false" default:"2"`
+	// ReadyTimeout bounds the ready check: the game starts without the cowboys that did not confirm by then, 0 waits
+	// for them forever.
+	ReadyTimeout time.Duration `envconfig:"READY_TIMEOUT" required:"false" default:"30s"`
+	// ShotCooldown is the shortest delay between two shots of any cowboy, slowing down the faster ones. 0 leaves it
+	// to the speed and the weapon of every cowboy.
+	ShotCooldown time.Duration `envconfig:"SHOT_COOLDOWN" required:"false" default:"0"`
+	// SnapshotEvery is how often a full round is published to the players, the rounds in between being deltas.
+	SnapshotEvery int `envconfig:"SNAPSHOT_EVERY" required:"false" default:"10"`
+}
+
+// Grid returns the battlefield of a positional game, nil when ARENA_SIZE is not set.
+func (c *MasterConfig) Grid() (*Grid, error) {
+	return ParseGrid(c.ArenaSize, c.ArenaCover)
+}
+
+// StallPolicy decides what happens to a game where nobody took damage for StallRounds consecutive rounds.
+type StallPolicy string
+
+const (
+	// StallWait keeps the game running.
+	StallWait StallPolicy = "wait"
+	// StallHighestHealth declares the cowboy with the highest health the winner.
+	StallHighestHealth StallPolicy = "highest_health"
+	// StallSuddenDeath escalates the damage of every shot round after round.
+	StallSuddenDeath StallPolicy = "sudden_death"
+	// StallAbort aborts the game.
+	StallAbort StallPolicy = "abort"
+)
+
+// Valid reports whether the policy is a known one.
+func (p StallPolicy) Valid() bool {
+	switch p {
+	case StallWait, StallHighestHealth, StallSuddenDeath, StallAbort:
+		return true
+	default:
+		return false
+	}
 }
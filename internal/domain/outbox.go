@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// OutboxConfig bounds the queue of the events a process publishes, and how a failed publish is retried.
+type OutboxConfig struct {
+	OutboxSize       int           `envconfig:"OUTBOX_SIZE"        required:"false" default:"256"`
+	OutboxPolicy     DropPolicy    `envconfig:"OUTBOX_POLICY"      required:"false" default:"drop_oldest"`
+	OutboxRetries    int           `envconfig:"OUTBOX_RETRIES"     required:"false" default:"8"`
+	OutboxBackoff    time.Duration `envconfig:"OUTBOX_BACKOFF"     required:"false" default:"50ms"`
+	OutboxMaxBackoff time.Duration `envconfig:"OUTBOX_MAX_BACKOFF" required:"false" default:"1s"`
+}
+
+// Validate checks the outbox can be built from the configuration.
+func (c *OutboxConfig) Validate() error {
+	if c.OutboxPolicy != "" && !c.OutboxPolicy.Valid() {
+		return fmt.Errorf("unknown outbox policy %q", c.OutboxPolicy)
+	}
+
+	if c.OutboxSize < 0 || c.OutboxRetries < 0 || c.OutboxBackoff < 0 || c.OutboxMaxBackoff < 0 {
+		return fmt.Errorf("outbox size, retries and backoffs can not be negative")
+	}
+
+	return nil
+}
+
+// DropPolicy decides which event a full outbox loses.
+type DropPolicy string
+
+const (
+	// DropOldest makes room for the new event by dropping the oldest queued one.
+	DropOldest DropPolicy = "drop_oldest"
+	// DropNewest drops the new event.
+	DropNewest DropPolicy = "drop_newest"
+)
+
+// Valid reports whether the policy is a known one.
+func (p DropPolicy) Valid() bool {
+	return p == DropOldest || p == DropNewest
+}
@@ -1,29 +1,162 @@
 package domain
 
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotAdmitted is returned to a cowboy the game refuses, having started or ended without it.
+var ErrNotAdmitted = errors.New("not admitted to the game")
+
+const (
+	// ExitNotAdmitted is the exit code of a player process the game did not admit, which restarting does not help.
+	ExitNotAdmitted = 2
+	// DefaultHeartbeatTimeout is how long a player waits for the master when no timeout is configured.
+	DefaultHeartbeatTimeout = 2 * time.Second
+)
+
 type PlayerConfig struct {
-	RedisAddr  string `envconfig:"REDIS_ADDR"           required:"false" default:"redis:6379"`
-	MasterAddr string `envconfig:"MASTER_ADDR"          required:"false" default:"http://master:8080"`
-	Name       string `envconfig:"NAME"                 required:"true"`
-	Health     int    `envconfig:"HEALTH"               required:"false" default:"10"`
-	Damage     int    `envconfig:"DAMAGE"               required:"false" default:"1"`
+	RedisConfig
+	OutboxConfig
+	SubscriptionConfig
+
+	MasterAddr      string        `envconfig:"MASTER_ADDR"          required:"false" default:"http://master:8080"`
+	GRPCAddr        string        `envconfig:"GRPC_ADDR"            required:"false"`
+	Name            string        `envconfig:"NAME"                 required:"true"`
+	Health          int           `envconfig:"HEALTH"               required:"false" default:"10"`
+	Damage          int           `envconfig:"DAMAGE"               required:"false" default:"1"`
+	Speed           float64       `envconfig:"SPEED"                required:"false" default:"1"`
+	Strategy        string        `envconfig:"STRATEGY"             required:"false" default:"random"`
+	StrategyURL     string        `envconfig:"STRATEGY_URL"         required:"false"`
+	StrategyTimeout time.Duration `envconfig:"STRATEGY_TIMEOUT"     required:"false" default:"500ms"`
+	Interactive     bool          `envconfig:"INTERACTIVE"          required:"false" default:"false"`
+	ChoiceTimeout   time.Duration `envconfig:"CHOICE_TIMEOUT"       required:"false" default:"900ms"`
+	Chaos           string        `envconfig:"CHAOS"                required:"false"`
+	Weapon          string        `envconfig:"WEAPON"               required:"false"`
+	Magazine        int           `envconfig:"MAGAZINE"             required:"false" default:"0"`
+	ReloadTime      time.Duration `envconfig:"RELOAD_TIME"          required:"false" default:"2s"`
+	FireRate        float64       `envconfig:"FIRE_RATE"            required:"false" default:"0"`
+	ReloadAt        int           `envconfig:"RELOAD_AT"            required:"false" default:"0"`
+	WeaponEffects   OnHits        `envconfig:"WEAPON_EFFECTS"       required:"false"`
+	Allies          []string      `envconfig:"ALLIES"               required:"false"`
+	AcceptAlliances bool          `envconfig:"ACCEPT_ALLIANCES"     required:"false" default:"false"`
+	// HeartbeatTimeout is how long the player waits for an event of the master before giving up.
+	HeartbeatTimeout time.Duration `envconfig:"HEARTBEAT_TIMEOUT" required:"false" default:"2s"`
+	// ResubscribeTimeout is how long the player waits for its broken subscription to come back before giving up.
+	ResubscribeTimeout time.Duration `envconfig:"RESUBSCRIBE_TIMEOUT" required:"false" default:"30s"`
+}
+
+// Arm returns the weapon the cowboy registers with.
+func (c *PlayerConfig) Arm() *Weapon {
+	return &Weapon{
+		Name:       c.Weapon,
+		Damage:     c.Damage,
+		Magazine:   c.Magazine,
+		ReloadTime: c.ReloadTime,
+		FireRate:   c.FireRate,
+		Effects:    c.WeaponEffects,
+	}
+}
+
+// Heartbeat returns how long the player waits for an event of the master, DefaultHeartbeatTimeout unless
+// configured.
+func (c *PlayerConfig) Heartbeat() time.Duration {
+	if c.HeartbeatTimeout <= 0 {
+		return DefaultHeartbeatTimeout
+	}
+
+	return c.HeartbeatTimeout
 }
 
 type Player struct {
 	ID     string `json:"id"`
 	Name   string `json:"name"`
 	Health int    `json:"health"`
-	Damage int    `json:"damage"`
+	// Damage is the damage of a shot, the one of the weapon when the cowboy registered with a weapon.
+	Damage int     `json:"damage"`
+	Weapon *Weapon `json:"weapon,omitempty"`
+	// Ammo is what is left in the magazine and Reloading tells whether the cowboy is reloading,
+	// everybody sees it.
+	Ammo      int  `json:"ammo,omitempty"`
+	Reloading bool `json:"reloading,omitempty"`
+	// Speed is the number of shots per second of the cowboy, 0 lets it shoot once per round.
+	Speed float64 `json:"speed,omitempty"`
+	// Shots is the number of shots the cooldown timeline of the cowboy grants it in the round.
+	Shots int `json:"shots,omitempty"`
+	// Position is the tile of the cowboy in a positional game.
+	Position *Position `json:"position,omitempty"`
+	// Effects are the status effects the cowboy is under.
+	Effects []*Effect `json:"effects,omitempty"`
+	// MaxHealth is the health the cowboy registered with, which heals can not exceed.
+	MaxHealth int `json:"max_health,omitempty"`
+	// Heals is the number of heals the cowboy has left.
+	Heals int `json:"heals,omitempty"`
+	// Allies are the IDs of the cowboys allied with the cowboy, sorted.
+	Allies []string `json:"allies,omitempty"`
+}
+
+// IsAlly reports whether the cowboy is allied with the cowboy id.
+func (c *Player) IsAlly(id string) bool {
+	for _, ally := range c.Allies {
+		if ally == id {
+			return true
+		}
+	}
+
+	return false
 }
 
+// IsEmpty reports whether the player has neither an identity nor stats.
 func (c *Player) IsEmpty() bool {
-	return Player{} == *c
+	return c.ID == "" && c.Name == "" && c.Health == 0 && c.Damage == 0 && c.Weapon == nil
+}
+
+// Cooldown is the delay between two shots of the cowboy, the longest of its speed and the fire rate of its weapon.
+func (c *Player) Cooldown() time.Duration {
+	var cooldown time.Duration
+	if c.Speed > 0 {
+		cooldown = time.Duration(float64(time.Second) / c.Speed)
+	}
+
+	if c.Weapon != nil && c.Weapon.Cooldown() > cooldown {
+		cooldown = c.Weapon.Cooldown()
+	}
+
+	return cooldown
 }
 
 type Round struct {
 	Players map[string]*Player
+	// Number identifies the round, lockstep mode resolves shots round by round.
+	Number int `json:"number,omitempty"`
+	// DamageMultiplier is applied to every shot once the game entered sudden death.
+	DamageMultiplier int `json:"damage_multiplier,omitempty"`
+	// Grid is the battlefield of a positional game.
+	Grid *Grid `json:"grid,omitempty"`
+	// Delta marks a round carrying only the cowboys that changed since the previous round and the IDs of the
+	// Removed ones, a round without it is a full snapshot.
+	Delta   bool     `json:"delta,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// Ready confirms that a registered cowboy is subscribed to the master events.
+type Ready struct {
+	ID string `json:"id"`
+}
+
+// Countdown announces the instant the encounter starts. ServerTime is the master clock
+// at emission, so a player can measure its offset to the master on receipt.
+type Countdown struct {
+	StartAt    time.Time `json:"start_at"`
+	ServerTime time.Time `json:"server_time"`
+}
+
+// Offset returns how far the local clock is ahead of the master clock, measured at receivedAt.
+func (c *Countdown) Offset(receivedAt time.Time) time.Duration {
+	return receivedAt.Sub(c.ServerTime)
 }
 
-type Action struct {
-	Src  string `json:"from"`
-	Dest string `json:"to"`
+// LocalStart returns the start instant expressed in the local clock.
+func (c *Countdown) LocalStart(receivedAt time.Time) time.Time {
+	return c.StartAt.Add(c.Offset(receivedAt))
 }
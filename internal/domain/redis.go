@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RedisConfig selects the Redis deployment the cowboys talk through: a single node, a Sentinel failover
+// group when RedisMasterName is set, or a Cluster. RedisAddrs is then the list of sentinels or seed nodes.
+// Namespace and GameID prefix every topic and key, so that several games can share one Redis, and Codec
+// encodes the events published over it.
+type RedisConfig struct {
+	Namespace          string        `envconfig:"NAMESPACE"               required:"false" default:"cowboys"`
+	GameID             string        `envconfig:"GAME_ID"                 required:"false" default:"default"`
+	Codec              string        `envconfig:"CODEC"                   required:"false" default:"json"`
+	RedisAddrs         []string      `envconfig:"REDIS_ADDR"              required:"false" default:"redis:6379"`
+	RedisMasterName    string        `envconfig:"REDIS_MASTER_NAME"       required:"false"`
+	RedisCluster       bool          `envconfig:"REDIS_CLUSTER"           required:"false" default:"false"`
+	RedisUsername      string        `envconfig:"REDIS_USERNAME"          required:"false"`
+	RedisPassword      string        `envconfig:"REDIS_PASSWORD"          required:"false"`
+	RedisSentinelUser  string        `envconfig:"REDIS_SENTINEL_USERNAME" required:"false"`
+	RedisSentinelPass  string        `envconfig:"REDIS_SENTINEL_PASSWORD" required:"false"`
+	RedisDB            int           `envconfig:"REDIS_DB"                required:"false" default:"0"`
+	RedisTLS           bool          `envconfig:"REDIS_TLS"               required:"false" default:"false"`
+	RedisTLSCA         string        `envconfig:"REDIS_TLS_CA"            required:"false"`
+	RedisTLSServerName string        `envconfig:"REDIS_TLS_SERVER_NAME"   required:"false"`
+	RedisTLSInsecure   bool          `envconfig:"REDIS_TLS_INSECURE"      required:"false" default:"false"`
+	RedisDialTimeout   time.Duration `envconfig:"REDIS_DIAL_TIMEOUT"      required:"false" default:"5s"`
+	RedisReadTimeout   time.Duration `envconfig:"REDIS_READ_TIMEOUT"      required:"false" default:"3s"`
+	RedisWriteTimeout  time.Duration `envconfig:"REDIS_WRITE_TIMEOUT"     required:"false" default:"3s"`
+	RedisPoolSize      int           `envconfig:"REDIS_POOL_SIZE"         required:"false" default:"0"`
+}
+
+// Key returns the name of a topic or a key of the game, namespace:game:name.
+func (c *RedisConfig) Key(name string) string {
+	return c.Namespace + ":" + c.GameID + ":" + name
+}
+
+// ValidateKeys checks the namespace and the game ID can prefix the keys unambiguously.
+func (c *RedisConfig) ValidateKeys() error {
+	if c.GameID == "" {
+		return fmt.Errorf("game ID can not be empty")
+	}
+
+	if strings.Contains(c.Namespace, ":") || strings.Contains(c.GameID, ":") {
+		return fmt.Errorf("namespace %q and game ID %q can not contain a colon", c.Namespace, c.GameID)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package domain
+
+// Kill records a cowboy eliminated by a shot. Shooter is empty when the game rules eliminated the target.
+type Kill struct {
+	Shooter string `json:"shooter,omitempty"`
+	Target  string `json:"target"`
+}
+
+// Result is the outcome of a finished game.
+type Result struct {
+	// Winner is nil when the game ended in a draw or was aborted.
+	Winner *Player `json:"winner,omitempty"`
+	Draw   bool    `json:"draw,omitempty"`
+	// Reason explains why an unfinished game was aborted.
+	Reason string `json:"reason,omitempty"`
+	// Standings ranks the cowboys from the winner to the first one eliminated.
+	Standings []*Player `json:"standings"`
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+)
+
+// ErrMissedRound is returned when applying a delta to a round other than the one right before it.
+var ErrMissedRound = errors.New("missed round")
+
+// Resync asks the master for a full snapshot, Last being the last round the cowboy knows.
+type Resync struct {
+	ID   string `json:"id"`
+	Last int    `json:"last"`
+}
+
+// Diff returns the delta leading from prev to the round. The grid never changes during a game, so it is left out.
+func (r *Round) Diff(prev *Round) *Round {
+	delta := &Round{
+		Players:          make(map[string]*Player),
+		Number:           r.Number,
+		DamageMultiplier: r.DamageMultiplier,
+		Delta:            true,
+	}
+
+	for id, player := range r.Players {
+		if before, ok := prev.Players[id]; !ok || !reflect.DeepEqual(before, player) {
+			delta.Players[id] = player
+		}
+	}
+
+	for id := range prev.Players {
+		if _, ok := r.Players[id]; !ok {
+			delta.Removed = append(delta.Removed, id)
+		}
+	}
+
+	sort.Strings(delta.Removed)
+
+	return delta
+}
+
+// Apply returns the round the update leads to from r. A full snapshot is returned as is, a delta must follow r.
+// The round r is left untouched.
+func (r *Round) Apply(update *Round) (*Round, error) {
+	if !update.Delta {
+		return update, nil
+	}
+
+	if r == nil || update.Number != r.Number+1 {
+		return nil, ErrMissedRound
+	}
+
+	round := &Round{
+		Players:          make(map[string]*Player, len(r.Players)+len(update.Players)),
+		Number:           update.Number,
+		DamageMultiplier: update.DamageMultiplier,
+		Grid:             r.Grid,
+	}
+
+	for id, player := range r.Players {
+		round.Players[id] = player
+	}
+
+	for id, player := range update.Players {
+		round.Players[id] = player
+	}
+
+	for _, id := range update.Removed {
+		delete(round.Players, id)
+	}
+
+	return round, nil
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Phase is the stage a game is in.
+type Phase string
+
+const (
+	PhaseWaiting    Phase = "waiting"
+	PhaseReadyCheck Phase = "ready_check"
+	PhaseCountdown  Phase = "countdown"
+	PhaseFighting   Phase = "fighting"
+	PhaseFinished   Phase = "finished"
+)
+
+// State is a snapshot of a game.
+type State struct {
+	Phase Phase `json:"phase"`
+	Round int   `json:"round"`
+	// Players are the cowboys still standing, Eliminated the ones who died in the order they died.
+	Players    []*Player `json:"players"`
+	Eliminated []*Player `json:"eliminated"`
+	StartAt    time.Time `json:"start_at,omitempty"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SubscriptionConfig configures how a broken subscription is resubscribed.
+type SubscriptionConfig struct {
+	ResubscribeBackoff    time.Duration `envconfig:"RESUBSCRIBE_BACKOFF"     required:"false" default:"100ms"`
+	ResubscribeMaxBackoff time.Duration `envconfig:"RESUBSCRIBE_MAX_BACKOFF" required:"false" default:"5s"`
+}
+
+// Validate checks the resubscription backoffs.
+func (c *SubscriptionConfig) Validate() error {
+	if c.ResubscribeBackoff < 0 || c.ResubscribeMaxBackoff < 0 {
+		return fmt.Errorf("resubscription backoffs can not be negative")
+	}
+
+	return nil
+}
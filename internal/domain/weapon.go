@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Weapon is the gun a cowboy registers with.
+type Weapon struct {
+	Name   string `json:"name,omitempty"`
+	Damage int    `json:"damage"`
+	// Magazine is the number of shots before a reload, 0 means the weapon never needs one.
+	Magazine   int           `json:"magazine,omitempty"`
+	ReloadTime time.Duration `json:"reload_time,omitempty"`
+	// FireRate is the maximum number of shots per second, 0 means unlimited.
+	FireRate float64 `json:"fire_rate,omitempty"`
+	// Effects are applied when a shot hits.
+	Effects OnHits `json:"effects,omitempty"`
+}
+
+// Validate checks the weapon can be fired.
+func (w *Weapon) Validate() error {
+	switch {
+	case w.Damage < 1:
+		return fmt.Errorf("weapon damage must be positive")
+	case w.Magazine < 0, w.ReloadTime < 0, w.FireRate < 0:
+		return fmt.Errorf("weapon magazine, reload time and fire rate can not be negative")
+	}
+
+	for _, effect := range w.Effects {
+		if err := effect.Validate(); err != nil {
+			return fmt.Errorf("weapon effect: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Cooldown is the minimum delay between two shots.
+func (w *Weapon) Cooldown() time.Duration {
+	if w.FireRate <= 0 {
+		return 0
+	}
+
+	return time.Duration(float64(time.Second) / w.FireRate)
+}
+
+// Reload asks the master to reload the weapon of a cowboy before its magazine is empty.
+type Reload struct {
+	ID string `json:"id"`
+}
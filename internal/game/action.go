@@ -0,0 +1,76 @@
+package game
+
+import (
+	"log"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// turn checks that a cowboy takes a single kind of action in a round, shooting being the only one
+// it can repeat. The action is recorded once it succeeded.
+func (gs *Game) turn(player *domain.Player, kind domain.ActionKind) error {
+	if done, ok := gs.acted[player.ID]; ok && (done != kind || kind != domain.ActionShoot) {
+		return ErrAlreadyActed
+	}
+
+	return nil
+}
+
+// act applies an action that is neither a shot nor a move. Dodge and cover last until the next round,
+// aim until the shot of the next round.
+func (gs *Game) act(player *domain.Player, kind domain.ActionKind) error {
+	if err := gs.turn(player, kind); err != nil {
+		return err
+	}
+
+	switch kind {
+	case domain.ActionDodge:
+		gs.applyEffect(player, domain.Effect{Kind: domain.EffectDodge, Rounds: 1}, player.Name)
+	case domain.ActionCover:
+		gs.applyEffect(player, domain.Effect{Kind: domain.EffectCover, Rounds: 1}, player.Name)
+	case domain.ActionAim:
+		gs.applyEffect(player, domain.Effect{Kind: domain.EffectAim, Rounds: domain.AimRounds}, player.Name)
+	case domain.ActionHeal:
+		if err := gs.heal(player); err != nil {
+			return err
+		}
+	}
+
+	gs.acted[player.ID] = kind
+
+	return nil
+}
+
+// heal restores the heal points of the game to the player, up to the health it registered with.
+func (gs *Game) heal(player *domain.Player) error {
+	if player.Heals < 1 {
+		return ErrNoHealLeft
+	}
+
+	player.Heals--
+
+	healed := gs.healPoints
+	if missing := player.MaxHealth - player.Health; healed > missing {
+		healed = missing
+	}
+
+	if healed > 0 {
+		player.Health += healed
+	}
+
+	log.Printf("%s heals %d health", player.Name, healed)
+
+	return nil
+}
+
+// strike resolves a shot the player fired and returns the damage it deals, with false when it missed.
+// The aim of the shooter is spent, hit or miss.
+func (gs *Game) strike(from, to *domain.Player) (int, bool) {
+	defer consume(from, domain.EffectAim)
+
+	if !gs.hits(from, to) {
+		return 0, false
+	}
+
+	return gs.damage(from, to), true
+}
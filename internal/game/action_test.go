@@ -0,0 +1,172 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// duelists are two cowboys of equal health, the second one hitting harder.
+func duelists() []*domain.Player {
+	return []*domain.Player{
+		{ID: "test_1", Name: "Test1", Health: 10, Damage: 2},
+		{ID: "test_2", Name: "Test2", Health: 10, Damage: 3},
+	}
+}
+
+func act(state *Game, id string, kind domain.ActionKind, round int) error {
+	action, _ := NewEvent(EventAction, &domain.Action{Src: id, Kind: kind, Round: round})
+	return state.HandleEvent(action)
+}
+
+func TestGameAimAndCover(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, duelists()...)
+	emitRound(t, state)
+
+	if err := act(state, "test_1", domain.ActionAim, 0); err != nil {
+		t.Fatalf("unexpected aim err: %v", err)
+	}
+
+	// A cowboy takes a single kind of action in a round.
+	if err := fire(state, "test_1", "test_2"); err != ErrAlreadyActed {
+		t.Fatalf("expected ErrAlreadyActed when shooting after aiming, got: %v", err)
+	}
+
+	if err := act(state, "test_2", domain.ActionCover, 0); err != nil {
+		t.Fatalf("unexpected cover err: %v", err)
+	}
+
+	// The aim lasts until the next round, the cover does not.
+	round := emitRound(t, state)
+	if len(round.Players["test_1"].Effects) != 1 || len(round.Players["test_2"].Effects) != 0 {
+		t.Fatalf("expected test_1 to aim and test_2 to leave cover, got %+v and %+v", round.Players["test_1"].Effects, round.Players["test_2"].Effects)
+	}
+
+	if err := act(state, "test_2", domain.ActionCover, 0); err != nil {
+		t.Fatalf("unexpected cover err: %v", err)
+	}
+
+	// An aimed shot deals twice the damage, halved by the cover, and the aim is spent.
+	for _, expected := range []int{8, 7} {
+		if err := fire(state, "test_1", "test_2"); err != nil {
+			t.Fatalf("unexpected shot err: %v", err)
+		}
+
+		if health := state.players["test_2"].Health; health != expected {
+			t.Fatalf("expected test_2 to have %d health, got %d", expected, health)
+		}
+	}
+
+	if err := act(state, "test_2", domain.ActionDodge, 0); err != ErrAlreadyActed {
+		t.Fatalf("expected ErrAlreadyActed when dodging after taking cover, got: %v", err)
+	}
+}
+
+func TestGameHeal(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{Heals: 2, HealPoints: 3}, duelists()...)
+
+	round := emitRound(t, state)
+	if player := round.Players["test_1"]; player.MaxHealth != 10 || player.Heals != 2 {
+		t.Fatalf("expected 10 max health and 2 heals, got %+v", player)
+	}
+
+	if err := fire(state, "test_2", "test_1"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	emitRound(t, state)
+
+	// Heals never exceed the health the cowboy registered with.
+	for _, expected := range []int{10, 10} {
+		if err := act(state, "test_1", domain.ActionHeal, 0); err != nil {
+			t.Fatalf("unexpected heal err: %v", err)
+		}
+
+		if health := state.players["test_1"].Health; health != expected {
+			t.Fatalf("expected test_1 to have %d health, got %d", expected, health)
+		}
+
+		emitRound(t, state)
+	}
+
+	if err := act(state, "test_1", domain.ActionHeal, 0); err != ErrNoHealLeft {
+		t.Fatalf("expected ErrNoHealLeft, got: %v", err)
+	}
+}
+
+func TestGameDodge(t *testing.T) {
+	hits := func(seed int64) int {
+		state := newGame(t, &domain.MasterConfig{Seed: seed}, duelists()...)
+
+		var count int
+		for i := 0; i < 20; i++ {
+			emitRound(t, state)
+			state.players["test_2"].Health = 10
+
+			if err := act(state, "test_2", domain.ActionDodge, 0); err != nil {
+				t.Fatalf("unexpected dodge err: %v", err)
+			}
+
+			if err := fire(state, "test_1", "test_2"); err != nil {
+				t.Fatalf("unexpected shot err: %v", err)
+			}
+
+			if state.players["test_2"].Health < 10 {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	count := hits(7)
+	if count == 0 || count == 20 {
+		t.Fatalf("expected a dodging cowboy to be hit sometimes, got %d hits out of 20", count)
+	}
+
+	if again := hits(7); again != count {
+		t.Fatalf("expected the same seed to give the same hits, got %d then %d", count, again)
+	}
+}
+
+func TestGameInvalidAction(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, duelists()...)
+	emitRound(t, state)
+
+	for name, action := range map[string]*domain.Action{
+		"unknown":         {Src: "test_1", Kind: "duel"},
+		"dodge at":        {Src: "test_1", Dest: "test_2", Kind: domain.ActionDodge},
+		"shot without to": {Src: "test_1", Kind: domain.ActionShoot},
+	} {
+		event, _ := NewEvent(EventAction, action)
+		if err := state.HandleEvent(event); !errors.Is(err, ErrInvalidPayload) {
+			t.Errorf("%s: expected ErrInvalidPayload, got: %v", name, err)
+		}
+	}
+}
+
+func TestGameLockstepActions(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{Lockstep: true},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 10, Damage: 3},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 10, Damage: 3},
+	)
+
+	round := emitRound(t, state)
+
+	// The cover is taken before the shots of the round are resolved, and replaces the shot queued before.
+	shoot(t, state, "test_1", "test_2", round.Number)
+	shoot(t, state, "test_2", "test_1", round.Number)
+	if err := act(state, "test_2", domain.ActionCover, round.Number); err != nil {
+		t.Fatalf("unexpected cover err: %v", err)
+	}
+
+	round = emitRound(t, state)
+	if round.Players["test_1"].Health != 10 || round.Players["test_2"].Health != 8 {
+		t.Fatalf("expected a single halved shot, got %+v and %+v", round.Players["test_1"], round.Players["test_2"])
+	}
+
+	if len(round.Players["test_2"].Effects) != 0 {
+		t.Fatalf("expected the cover to end with the round, got %+v", round.Players["test_2"].Effects)
+	}
+}
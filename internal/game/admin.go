@@ -0,0 +1,136 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// Administer applies an operator action and records it. Pausing the game and changing its tick
+// interval are up to the master, the game only records them.
+func (gs *Game) Administer(action *domain.AdminAction) error {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	if gs.gameFinished {
+		return ErrGameFinished
+	}
+
+	switch action.Command {
+	case domain.AdminPause, domain.AdminResume:
+	case domain.AdminTick:
+		if action.Interval <= 0 {
+			return fmt.Errorf("%w: tick interval must be positive", ErrInvalidPayload)
+		}
+	case domain.AdminKick:
+		if _, ok := gs.players[action.Target]; !ok {
+			return ErrUnknownPlayer
+		}
+	case domain.AdminAdjust:
+		if _, ok := gs.players[action.Target]; !ok {
+			return ErrUnknownPlayer
+		}
+
+		if action.Health == nil && action.Damage == nil ||
+			action.Damage != nil && *action.Damage < 1 ||
+			action.Health != nil && *action.Health < 1 && !gs.gameStarted {
+			return ErrInvalidPayload
+		}
+	case domain.AdminEnd:
+		if _, ok := gs.players[action.Target]; !ok {
+			return ErrUnknownPlayer
+		}
+	case domain.AdminEffect:
+		if _, ok := gs.players[action.Target]; !ok {
+			return ErrUnknownPlayer
+		}
+
+		if action.Effect == nil {
+			return fmt.Errorf("%w: missing effect", ErrInvalidPayload)
+		}
+
+		if err := action.Effect.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+	default:
+		return fmt.Errorf("%w: unknown admin command %q", ErrInvalidPayload, action.Command)
+	}
+
+	gs.record(EventAdmin, action)
+
+	switch action.Command {
+	case domain.AdminKick:
+		gs.kick(action.Target)
+	case domain.AdminAdjust:
+		gs.adjust(action.Target, action.Health, action.Damage)
+	case domain.AdminEnd:
+		gs.declare(action.Target)
+	case domain.AdminEffect:
+		gs.applyEffect(gs.players[action.Target], *action.Effect, "")
+	}
+
+	return nil
+}
+
+// kick removes a cowboy. Before the roster is full, it frees the slot for another registration.
+func (gs *Game) kick(id string) {
+	log.Printf("%s was kicked", gs.players[id].Name)
+
+	if !gs.gameStarted {
+		delete(gs.players, id)
+		return
+	}
+
+	delete(gs.ready, id)
+	delete(gs.pending, id)
+	gs.eliminate(id, "")
+}
+
+// adjust sets the health and the damage of a cowboy. A cowboy left without health is eliminated.
+func (gs *Game) adjust(id string, health, damage *int) {
+	player := gs.players[id]
+
+	if health != nil {
+		player.Health = *health
+	}
+
+	if damage != nil {
+		player.Damage = *damage
+		player.Weapon.Damage = *damage
+	}
+
+	log.Printf("%s adjusted to health %d, damage %d", player.Name, player.Health, player.Damage)
+
+	if player.Health < 1 {
+		gs.eliminate(id, "")
+	}
+}
+
+// declare ends the game with the given winner. The other cowboys are eliminated from the weakest, by ID on equal
+// health.
+func (gs *Game) declare(id string) {
+	losers := make([]*domain.Player, 0, len(gs.players))
+	for _, player := range gs.players {
+		if player.ID != id {
+			losers = append(losers, player)
+		}
+	}
+
+	sort.SliceStable(losers, func(i, j int) bool {
+		a, b := losers[i], losers[j]
+		if a.Health != b.Health {
+			return a.Health < b.Health
+		}
+
+		return a.ID < b.ID
+	})
+
+	for _, player := range losers {
+		gs.eliminate(player.ID, "")
+	}
+
+	log.Printf("%s declared the winner", gs.players[id].Name)
+	gs.finish("")
+}
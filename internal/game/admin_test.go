@@ -0,0 +1,130 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// posse are three cowboys of different health.
+func posse() []*domain.Player {
+	return []*domain.Player{
+		{ID: "test_1", Name: "Test1", Health: 5, Damage: 1},
+		{ID: "test_2", Name: "Test2", Health: 3, Damage: 1},
+		{ID: "test_3", Name: "Test3", Health: 4, Damage: 1},
+	}
+}
+
+// record collects the events of the game from then on.
+func record(state *Game) *[]*Event {
+	var recorded []*Event
+	state.Observe(func(event *Event) {
+		recorded = append(recorded, event)
+	})
+
+	return &recorded
+}
+
+func TestGameAdminKickAndAdjust(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, posse()...)
+	recorded := record(state)
+	emitRound(t, state)
+
+	if err := state.Administer(&domain.AdminAction{Command: domain.AdminKick, Target: "test_2"}); err != nil {
+		t.Fatalf("unexpected kick err: %v", err)
+	}
+
+	if err := state.Administer(&domain.AdminAction{Command: domain.AdminKick, Target: "test_2"}); !errors.Is(err, ErrUnknownPlayer) {
+		t.Fatalf("expected ErrUnknownPlayer kicking twice, got: %v", err)
+	}
+
+	health, damage := 9, 4
+	adjust := &domain.AdminAction{Command: domain.AdminAdjust, Target: "test_3", Health: &health, Damage: &damage}
+	if err := state.Administer(adjust); err != nil {
+		t.Fatalf("unexpected adjust err: %v", err)
+	}
+
+	round := emitRound(t, state)
+	if _, ok := round.Players["test_2"]; ok || len(round.Players) != 2 {
+		t.Fatalf("expected test_2 to be kicked, got %+v", round.Players)
+	}
+
+	if player := round.Players["test_3"]; player.Health != 9 || player.Damage != 4 {
+		t.Fatalf("expected test_3 to be adjusted, got %+v", player)
+	}
+
+	expected := []EventType{EventAdmin, EventKill, EventAdmin}
+	journal := *recorded
+	if len(journal) != len(expected) {
+		t.Fatalf("expected %d recorded events, got %d", len(expected), len(journal))
+	}
+
+	for i, eventType := range expected {
+		if journal[i].Type != eventType {
+			t.Fatalf("expected recorded event %d to be %q, got %q", i, eventType, journal[i].Type)
+		}
+	}
+}
+
+func TestGameAdminEnd(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, posse()...)
+	emitRound(t, state)
+
+	if err := state.Administer(&domain.AdminAction{Command: domain.AdminEnd, Target: "test_2"}); err != nil {
+		t.Fatalf("unexpected end err: %v", err)
+	}
+
+	result := state.Result()
+	if result == nil || result.Winner == nil || result.Winner.ID != "test_2" {
+		t.Fatalf("expected test_2 to be declared the winner, got %+v", result)
+	}
+
+	standings := []string{"test_2", "test_1", "test_3"}
+	for i, id := range standings {
+		if result.Standings[i].ID != id {
+			t.Fatalf("expected %s at rank %d, got %s", id, i+1, result.Standings[i].ID)
+		}
+	}
+
+	if err := state.Administer(&domain.AdminAction{Command: domain.AdminPause}); err != ErrGameFinished {
+		t.Fatalf("expected ErrGameFinished, got: %v", err)
+	}
+}
+
+func TestGameAdminEndTie(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		state := newGame(t, &domain.MasterConfig{},
+			&domain.Player{ID: "test_1", Name: "Test1", Health: 5, Damage: 1},
+			&domain.Player{ID: "test_3", Name: "Test3", Health: 3, Damage: 1},
+			&domain.Player{ID: "test_2", Name: "Test2", Health: 3, Damage: 1},
+		)
+		emitRound(t, state)
+
+		if err := state.Administer(&domain.AdminAction{Command: domain.AdminEnd, Target: "test_1"}); err != nil {
+			t.Fatalf("unexpected end err: %v", err)
+		}
+
+		// The losers of equal health are eliminated by ID, the last one ranking first.
+		standings := []string{"test_1", "test_3", "test_2"}
+		for rank, id := range standings {
+			if got := state.Result().Standings[rank].ID; got != id {
+				t.Fatalf("expected %s at rank %d, got %s", id, rank+1, got)
+			}
+		}
+	}
+}
+
+func TestGameAdminInvalid(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, posse()...)
+
+	for _, action := range []*domain.AdminAction{
+		{Command: "explode"},
+		{Command: domain.AdminTick},
+		{Command: domain.AdminAdjust, Target: "test_1"},
+	} {
+		if err := state.Administer(action); !errors.Is(err, ErrInvalidPayload) {
+			t.Errorf("%s: expected ErrInvalidPayload, got: %v", action.Command, err)
+		}
+	}
+}
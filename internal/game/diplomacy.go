@@ -0,0 +1,144 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// proposal is an alliance offered by the cowboy from to the cowboy to.
+type proposal struct {
+	from, to string
+}
+
+// handlePlayerMessage checks and records a message between living cowboys.
+func (gs *Game) handlePlayerMessage(event *Event) error {
+	if !gs.gameStarted {
+		return ErrGameNotStarted
+	}
+
+	var message domain.Message
+	if err := json.Unmarshal(event.Data, &message); err != nil {
+		return fmt.Errorf("failed to unmarshal player message payload: %w", err)
+	}
+
+	switch {
+	case message.Text == "" || len(message.Text) > domain.MaxMessageLength:
+		return fmt.Errorf("%w: a message needs a text of at most %d bytes", ErrInvalidPayload, domain.MaxMessageLength)
+	case gs.players[message.From] == nil:
+		return ErrUnknownPlayer
+	case message.To != "" && gs.players[message.To] == nil:
+		return ErrUnknownPlayer
+	}
+
+	gs.record(EventMessage, &message)
+
+	return nil
+}
+
+// handlePlayerAlliance applies a diplomatic step between two living cowboys: a proposal is pending until
+// the other cowboy accepts it, and either ally can break the alliance.
+func (gs *Game) handlePlayerAlliance(event *Event) error {
+	if !gs.gameStarted {
+		return ErrGameNotStarted
+	}
+
+	var alliance domain.Alliance
+	if err := json.Unmarshal(event.Data, &alliance); err != nil {
+		return fmt.Errorf("failed to unmarshal player alliance payload: %w", err)
+	}
+
+	from, to := gs.players[alliance.From], gs.players[alliance.To]
+	switch {
+	case from == nil || to == nil:
+		return ErrUnknownPlayer
+	case from == to:
+		return fmt.Errorf("%w: a cowboy can not ally with itself", ErrInvalidPayload)
+	}
+
+	switch alliance.Kind {
+	case domain.AlliancePropose:
+		if from.IsAlly(to.ID) {
+			return ErrAlreadyAllied
+		}
+
+		gs.proposals[proposal{from: from.ID, to: to.ID}] = true
+	case domain.AllianceAccept:
+		if !gs.proposals[proposal{from: to.ID, to: from.ID}] {
+			return ErrNoProposal
+		}
+
+		gs.ally(from, to)
+	case domain.AllianceBreak:
+		if !from.IsAlly(to.ID) {
+			return ErrNotAllied
+		}
+
+		gs.split(from, to)
+	default:
+		return fmt.Errorf("%w: unknown alliance step %q", ErrInvalidPayload, alliance.Kind)
+	}
+
+	log.Printf("%s: %s %s", from.Name, alliance.Kind, to.Name)
+	gs.record(EventAlliance, &alliance)
+
+	return nil
+}
+
+// ally makes the two cowboys allies and forgets their proposals.
+func (gs *Game) ally(a, b *domain.Player) {
+	delete(gs.proposals, proposal{from: a.ID, to: b.ID})
+	delete(gs.proposals, proposal{from: b.ID, to: a.ID})
+
+	a.Allies = append(a.Allies, b.ID)
+	sort.Strings(a.Allies)
+	b.Allies = append(b.Allies, a.ID)
+	sort.Strings(b.Allies)
+}
+
+// split ends the alliance of the two cowboys.
+func (gs *Game) split(a, b *domain.Player) {
+	a.Allies = without(a.Allies, b.ID)
+	b.Allies = without(b.Allies, a.ID)
+}
+
+// betray ends the alliance of a cowboy shooting at its ally and records the betrayal.
+func (gs *Game) betray(traitor, victim *domain.Player) {
+	gs.split(traitor, victim)
+
+	log.Printf("%s betrays %s", traitor.Name, victim.Name)
+	gs.record(EventBetrayal, &domain.Betrayal{Traitor: traitor.Name, Victim: victim.Name})
+}
+
+// dissolve removes an eliminated cowboy from the alliances and the proposals.
+func (gs *Game) dissolve(player *domain.Player) {
+	for _, ally := range player.Allies {
+		if other, ok := gs.players[ally]; ok {
+			other.Allies = without(other.Allies, player.ID)
+		}
+	}
+
+	for pending := range gs.proposals {
+		if pending.from == player.ID || pending.to == player.ID {
+			delete(gs.proposals, pending)
+		}
+	}
+}
+
+func without(ids []string, id string) []string {
+	kept := make([]string, 0, len(ids))
+	for _, other := range ids {
+		if other != id {
+			kept = append(kept, other)
+		}
+	}
+
+	if len(kept) == 0 {
+		return nil
+	}
+
+	return kept
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+func negotiate(state *Game, from, to string, kind domain.AllianceKind) error {
+	event, _ := NewEvent(EventAlliance, &domain.Alliance{From: from, To: to, Kind: kind})
+	return state.HandleEvent(event)
+}
+
+func TestGameAlliance(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, posse()...)
+	recorded := record(state)
+	emitRound(t, state)
+
+	if err := negotiate(state, "test_1", "test_2", domain.AllianceAccept); err != ErrNoProposal {
+		t.Fatalf("expected ErrNoProposal, got: %v", err)
+	}
+
+	if err := negotiate(state, "test_1", "test_2", domain.AlliancePropose); err != nil {
+		t.Fatalf("unexpected proposal err: %v", err)
+	}
+
+	// Only the cowboy the alliance was proposed to can accept it.
+	if err := negotiate(state, "test_1", "test_2", domain.AllianceAccept); err != ErrNoProposal {
+		t.Fatalf("expected ErrNoProposal, got: %v", err)
+	}
+
+	if err := negotiate(state, "test_2", "test_1", domain.AllianceAccept); err != nil {
+		t.Fatalf("unexpected acceptance err: %v", err)
+	}
+
+	round := emitRound(t, state)
+	if !round.Players["test_1"].IsAlly("test_2") || !round.Players["test_2"].IsAlly("test_1") || len(round.Players["test_3"].Allies) != 0 {
+		t.Fatalf("expected test_1 and test_2 to be allies, got %+v", round.Players)
+	}
+
+	if err := negotiate(state, "test_2", "test_1", domain.AlliancePropose); err != ErrAlreadyAllied {
+		t.Fatalf("expected ErrAlreadyAllied, got: %v", err)
+	}
+
+	if err := negotiate(state, "test_2", "test_1", domain.AllianceBreak); err != nil {
+		t.Fatalf("unexpected break err: %v", err)
+	}
+
+	if err := negotiate(state, "test_1", "test_2", domain.AllianceBreak); err != ErrNotAllied {
+		t.Fatalf("expected ErrNotAllied, got: %v", err)
+	}
+
+	for name, err := range map[string]error{
+		"self":    negotiate(state, "test_1", "test_1", domain.AlliancePropose),
+		"unknown": negotiate(state, "test_1", "test_4", domain.AlliancePropose),
+		"kind":    negotiate(state, "test_1", "test_3", "marry"),
+	} {
+		if !errors.Is(err, ErrInvalidPayload) && !errors.Is(err, ErrUnknownPlayer) {
+			t.Errorf("%s: expected the step to be rejected, got: %v", name, err)
+		}
+	}
+
+	var steps []domain.AllianceKind
+	for _, event := range *recorded {
+		if event.Type == EventAlliance {
+			var alliance domain.Alliance
+			_ = json.Unmarshal(event.Data, &alliance)
+			steps = append(steps, alliance.Kind)
+		}
+	}
+
+	if len(steps) != 3 || steps[0] != domain.AlliancePropose || steps[1] != domain.AllianceAccept || steps[2] != domain.AllianceBreak {
+		t.Fatalf("expected the proposal, the acceptance and the break to be recorded, got %v", steps)
+	}
+}
+
+func TestGameBetrayal(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, posse()...)
+	recorded := record(state)
+	emitRound(t, state)
+
+	for _, step := range []*domain.Alliance{
+		{From: "test_1", To: "test_2", Kind: domain.AlliancePropose},
+		{From: "test_2", To: "test_1", Kind: domain.AllianceAccept},
+		{From: "test_3", To: "test_1", Kind: domain.AlliancePropose},
+	} {
+		if err := negotiate(state, step.From, step.To, step.Kind); err != nil {
+			t.Fatalf("unexpected %s err: %v", step.Kind, err)
+		}
+	}
+
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	var betrayals []domain.Betrayal
+	for _, event := range *recorded {
+		if event.Type == EventBetrayal {
+			var betrayal domain.Betrayal
+			_ = json.Unmarshal(event.Data, &betrayal)
+			betrayals = append(betrayals, betrayal)
+		}
+	}
+
+	if len(betrayals) != 1 || betrayals[0].Traitor != "Test1" || betrayals[0].Victim != "Test2" {
+		t.Fatalf("expected Test1 to betray Test2, got %+v", betrayals)
+	}
+
+	if len(state.players["test_1"].Allies) != 0 || len(state.players["test_2"].Allies) != 0 {
+		t.Fatalf("expected the betrayal to end the alliance, got %+v and %+v", state.players["test_1"], state.players["test_2"])
+	}
+
+	// The proposals of an eliminated cowboy are forgotten.
+	state.players["test_3"].Health = 1
+	if err := fire(state, "test_1", "test_3"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	if len(state.proposals) != 0 {
+		t.Fatalf("expected no proposal left, got %+v", state.proposals)
+	}
+}
+
+func TestGameMessage(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, posse()...)
+	recorded := record(state)
+
+	for name, message := range map[string]*domain.Message{
+		"private":   {From: "test_1", To: "test_2", Text: "truce?"},
+		"broadcast": {From: "test_3", Text: "draw!"},
+	} {
+		event, _ := NewEvent(EventMessage, message)
+		if err := state.HandleEvent(event); err != nil {
+			t.Fatalf("%s: unexpected err: %v", name, err)
+		}
+	}
+
+	var count int
+	for _, event := range *recorded {
+		if event.Type == EventMessage {
+			count++
+		}
+	}
+
+	if count != 2 {
+		t.Fatalf("expected 2 recorded messages, got %d", count)
+	}
+
+	for name, message := range map[string]*domain.Message{
+		"empty":    {From: "test_1", To: "test_2"},
+		"long":     {From: "test_1", To: "test_2", Text: strings.Repeat("a", domain.MaxMessageLength+1)},
+		"stranger": {From: "test_4", To: "test_2", Text: "hi"},
+		"ghost":    {From: "test_1", To: "test_4", Text: "hi"},
+	} {
+		event, _ := NewEvent(EventMessage, message)
+		if err := state.HandleEvent(event); !errors.Is(err, ErrInvalidPayload) && !errors.Is(err, ErrUnknownPlayer) {
+			t.Errorf("%s: expected the message to be rejected, got: %v", name, err)
+		}
+	}
+}
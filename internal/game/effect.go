@@ -0,0 +1,132 @@
+package game
+
+import (
+	"log"
+	"sort"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// applyEffect puts the player under the effect, following its stacking rule when the player already
+// suffers an effect of the same kind. source is the name of the cowboy who applied it, empty for an operator.
+func (gs *Game) applyEffect(player *domain.Player, effect domain.Effect, source string) {
+	effect.Source = source
+
+	current := effectOf(player, effect.Kind)
+	switch {
+	case current == nil:
+		effect.Stacks = 1
+		current = &effect
+		player.Effects = append(player.Effects, current)
+	case effect.Stack() == domain.StackIgnore:
+		return
+	case effect.Stack() == domain.StackIntensity:
+		if current.Stacks < domain.MaxStacks {
+			current.Stacks++
+		}
+
+		current.Rounds, current.Source = effect.Rounds, source
+	default:
+		current.Magnitude, current.Rounds, current.Source = effect.Magnitude, effect.Rounds, source
+	}
+
+	log.Printf("%s is under %s for %d rounds", player.Name, current.Kind, current.Rounds)
+
+	applied := *current
+	gs.record(EventEffect, &domain.Affliction{Target: player.Name, Effect: &applied})
+}
+
+// onHit applies the effects of the weapon of the shooter after a hit, each one rolling its chance.
+// Effects on a target eliminated by the shot are lost, the target being nil once a lockstep round removed it.
+func (gs *Game) onHit(from, to *domain.Player) {
+	for _, hit := range from.Weapon.Effects {
+		if hit.Chance > 0 && hit.Chance < 1 && gs.rnd.Float64() >= hit.Chance {
+			continue
+		}
+
+		target := to
+		if hit.Self {
+			target = from
+		}
+
+		if target == nil {
+			continue
+		}
+
+		if _, ok := gs.players[target.ID]; ok {
+			gs.applyEffect(target, hit.Effect, from.Name)
+		}
+	}
+}
+
+// tickEffects runs the effects at the opening of a round, in the order of the IDs of the cowboys:
+// bleeding deals its damage, which no defense reduces, then every effect loses a round and expires.
+func (gs *Game) tickEffects() {
+	ids := make([]string, 0, len(gs.players))
+	for id, player := range gs.players {
+		if len(player.Effects) > 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		player := gs.players[id]
+
+		if bleeding := effectOf(player, domain.EffectBleeding); bleeding != nil {
+			player.Health -= bleeding.Strength()
+			gs.damaged = true
+
+			log.Printf("%s bleeds %d damage", player.Name, bleeding.Strength())
+
+			if player.Health < 1 {
+				gs.eliminate(id, bleeding.Source)
+				continue
+			}
+		}
+
+		effects := player.Effects[:0]
+		for _, effect := range player.Effects {
+			if effect.Rounds--; effect.Rounds > 0 {
+				effects = append(effects, effect)
+			}
+		}
+
+		player.Effects = effects
+		if len(effects) == 0 {
+			player.Effects = nil
+		}
+	}
+}
+
+// consume removes the effect of the kind from the player and reports whether it was under it.
+func consume(player *domain.Player, kind domain.EffectKind) bool {
+	for i, effect := range player.Effects {
+		if effect.Kind == kind {
+			player.Effects = append(player.Effects[:i:i], player.Effects[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// strength returns the strength of the effect of the kind the player is under, 0 without it.
+func strength(player *domain.Player, kind domain.EffectKind) int {
+	if effect := effectOf(player, kind); effect != nil {
+		return effect.Strength()
+	}
+
+	return 0
+}
+
+func effectOf(player *domain.Player, kind domain.EffectKind) *domain.Effect {
+	for _, effect := range player.Effects {
+		if effect.Kind == kind {
+			return effect
+		}
+	}
+
+	return nil
+}
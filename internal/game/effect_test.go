@@ -0,0 +1,220 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+func afflict(t *testing.T, state *Game, target string, effect domain.Effect) {
+	t.Helper()
+
+	if err := state.Administer(&domain.AdminAction{Command: domain.AdminEffect, Target: target, Effect: &effect}); err != nil {
+		t.Fatalf("unexpected admin err: %v", err)
+	}
+}
+
+func TestGameBleeding(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, armed(&domain.Weapon{Damage: 1, Effects: domain.OnHits{
+		{Effect: domain.Effect{Kind: domain.EffectBleeding, Magnitude: 1, Rounds: 2}},
+	}})...)
+
+	var kills []domain.Kill
+	state.Observe(func(event *Event) {
+		if event.Type == EventKill {
+			var kill domain.Kill
+			_ = json.Unmarshal(event.Data, &kill)
+			kills = append(kills, kill)
+		}
+	})
+
+	emitRound(t, state)
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	round := emitRound(t, state)
+	target := round.Players["test_2"]
+	if target.Health != 8 || len(target.Effects) != 1 || target.Effects[0].Rounds != 1 || target.Effects[0].Source != "Test1" {
+		t.Fatalf("expected test_2 to bleed once, got %d health and %+v", target.Health, target.Effects)
+	}
+
+	// Bleeding stacks in intensity and its duration restarts.
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	for _, expected := range []int{5, 3, 3} {
+		round = emitRound(t, state)
+		if target = round.Players["test_2"]; target.Health != expected {
+			t.Fatalf("expected test_2 to have %d health, got %d with %+v", expected, target.Health, target.Effects)
+		}
+	}
+
+	if len(target.Effects) != 0 {
+		t.Fatalf("expected bleeding to expire, got %+v", target.Effects)
+	}
+
+	// A cowboy bleeding to death is a kill of the cowboy who made it bleed.
+	state.players["test_2"].Health = 2
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	emitRound(t, state)
+	if len(kills) != 1 || kills[0].Shooter != "Test1" || kills[0].Target != "Test2" {
+		t.Fatalf("expected Test1 to kill Test2, got %+v", kills)
+	}
+
+	if result := state.Result(); result == nil || result.Winner == nil || result.Winner.ID != "test_1" {
+		t.Fatalf("expected test_1 to win, got %+v", result)
+	}
+}
+
+func TestGameStun(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, armed(&domain.Weapon{Damage: 1, Magazine: 3})...)
+	emitRound(t, state)
+
+	afflict(t, state, "test_1", domain.Effect{Kind: domain.EffectStun, Rounds: 2})
+	// Stun ignores a second application.
+	afflict(t, state, "test_1", domain.Effect{Kind: domain.EffectStun, Rounds: 5})
+
+	if effects := state.players["test_1"].Effects; len(effects) != 1 || effects[0].Rounds != 2 {
+		t.Fatalf("expected a single stun of 2 rounds, got %+v", effects)
+	}
+
+	if err := fire(state, "test_1", "test_2"); err != ErrStunned {
+		t.Fatalf("expected ErrStunned, got: %v", err)
+	}
+
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("expected the stun to skip one shot only, got: %v", err)
+	}
+
+	round := emitRound(t, state)
+	if shooter, target := round.Players["test_1"], round.Players["test_2"]; shooter.Ammo != 2 || len(shooter.Effects) != 0 || target.Health != 9 {
+		t.Fatalf("expected a single shot to land, got shooter %+v and %d health", shooter, target.Health)
+	}
+}
+
+func TestGameBuffs(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, armed(&domain.Weapon{Damage: 1})...)
+	emitRound(t, state)
+
+	afflict(t, state, "test_1", domain.Effect{Kind: domain.EffectDamageBuff, Magnitude: 1, Rounds: 1})
+	// Buffs refresh: the last application replaces the magnitude and the duration.
+	afflict(t, state, "test_1", domain.Effect{Kind: domain.EffectDamageBuff, Magnitude: 3, Rounds: 2})
+	afflict(t, state, "test_2", domain.Effect{Kind: domain.EffectDefenseBuff, Magnitude: 1, Rounds: 1})
+
+	if effects := state.players["test_1"].Effects; len(effects) != 1 || effects[0].Magnitude != 3 || effects[0].Stacks != 1 {
+		t.Fatalf("expected a refreshed buff, got %+v", effects)
+	}
+
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	round := emitRound(t, state)
+	if target := round.Players["test_2"]; target.Health != 7 || len(target.Effects) != 0 {
+		t.Fatalf("expected 1+3-1 damage and an expired defense, got %d health and %+v", target.Health, target.Effects)
+	}
+
+	// A defense stronger than the shot absorbs it.
+	afflict(t, state, "test_1", domain.Effect{Kind: domain.EffectDefenseBuff, Magnitude: 5, Rounds: 1})
+	if err := fire(state, "test_2", "test_1"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	if round = emitRound(t, state); round.Players["test_1"].Health != 10 {
+		t.Fatalf("expected the shot to be absorbed, got %d health", round.Players["test_1"].Health)
+	}
+}
+
+func TestGameEffectChance(t *testing.T) {
+	applied := func(seed int64) int {
+		state := newGame(t, &domain.MasterConfig{Seed: seed},
+			&domain.Player{ID: "test_1", Name: "Test1", Health: 100, Weapon: &domain.Weapon{Damage: 1, Effects: domain.OnHits{
+				{Effect: domain.Effect{Kind: domain.EffectStun, Rounds: 1}, Chance: 0.5, Self: true},
+			}}},
+			&domain.Player{ID: "test_2", Name: "Test2", Health: 100, Damage: 1},
+		)
+
+		var count int
+		state.Observe(func(event *Event) {
+			if event.Type == EventEffect {
+				count++
+			}
+		})
+
+		for i := 0; i < 20; i++ {
+			emitRound(t, state)
+			if err := fire(state, "test_1", "test_2"); err != nil && err != ErrStunned {
+				t.Fatalf("unexpected shot err: %v", err)
+			}
+		}
+
+		return count
+	}
+
+	count := applied(42)
+	if count == 0 || count == 20 {
+		t.Fatalf("expected a chance of 0.5 to apply some of the effects, got %d out of 20", count)
+	}
+
+	if again := applied(42); again != count {
+		t.Fatalf("expected the same seed to apply the same effects, got %d then %d", count, again)
+	}
+}
+
+func TestGameLockstepEffects(t *testing.T) {
+	buff := &domain.Weapon{Damage: 1, Effects: domain.OnHits{
+		{Effect: domain.Effect{Kind: domain.EffectDamageBuff, Magnitude: 1, Rounds: 3}, Self: true},
+	}}
+
+	state := newGame(t, &domain.MasterConfig{Lockstep: true},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 10, Weapon: buff},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 10, Damage: 1},
+	)
+
+	round := emitRound(t, state)
+	shoot(t, state, "test_1", "test_2", round.Number)
+
+	// The buff applies once the round is resolved, not to the shot granting it.
+	round = emitRound(t, state)
+	if shooter, target := round.Players["test_1"], round.Players["test_2"]; target.Health != 9 || len(shooter.Effects) != 1 {
+		t.Fatalf("expected 1 damage and a buffed shooter, got %d health and %+v", target.Health, shooter.Effects)
+	}
+
+	shoot(t, state, "test_1", "test_2", round.Number)
+
+	if round = emitRound(t, state); round.Players["test_2"].Health != 7 {
+		t.Fatalf("expected a buffed shot, got %d health", round.Players["test_2"].Health)
+	}
+}
+
+func TestGameLockstepEffectsOnKill(t *testing.T) {
+	bleeding := &domain.Weapon{Damage: 5, Effects: domain.OnHits{
+		{Effect: domain.Effect{Kind: domain.EffectBleeding, Magnitude: 1, Rounds: 2}},
+		{Effect: domain.Effect{Kind: domain.EffectDamageBuff, Magnitude: 1, Rounds: 2}, Self: true},
+	}}
+
+	state := newGame(t, &domain.MasterConfig{Lockstep: true},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 10, Weapon: bleeding},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 5, Damage: 1},
+		&domain.Player{ID: "test_3", Name: "Test3", Health: 10, Damage: 1},
+	)
+
+	round := emitRound(t, state)
+	shoot(t, state, "test_1", "test_2", round.Number)
+
+	// The bleeding of the killed target is lost, the buff of the shooter still applies.
+	round = emitRound(t, state)
+	if _, ok := round.Players["test_2"]; ok {
+		t.Fatalf("expected test_2 to be killed, got %+v", round.Players)
+	}
+
+	if shooter := round.Players["test_1"]; len(shooter.Effects) != 1 || shooter.Effects[0].Kind != domain.EffectDamageBuff {
+		t.Fatalf("expected a buffed shooter, got %+v", shooter.Effects)
+	}
+}
@@ -6,17 +6,36 @@ import (
 )
 
 const (
-	Heartbeat    EventType = "heartbeat"
-	Registration           = "registration"
-	EventRound             = "round"
-	EventShot                   = "shot"
+	Heartbeat       EventType = "heartbeat"
+	Registration              = "registration"
+	EventReadyCheck           = "ready_check"
+	EventReady                = "ready"
+	EventCountdown            = "countdown"
+	EventRound                = "round"
+	EventShot                 = "shot"
+	EventAction               = "action"
+	EventReload               = "reload"
+	EventKill                 = "kill"
+	EventGameOver             = "game_over"
+	EventAdmin                = "admin"
+	EventEffect               = "effect"
+	EventMessage              = "message"
+	EventAlliance             = "alliance"
+	EventBetrayal             = "betrayal"
+	EventResync               = "resync"
 )
 
 type EventType string
 
+// Observer is notified of the events recorded by the game. It is called with the game locked,
+// so it must neither block nor call back into the game.
+type Observer func(*Event)
+
 type Event struct {
 	Type EventType       `json:"type"`
 	Data json.RawMessage `json:"data,omitempty"`
+	// Game is the ID of the game the event belongs to, set on the events published over Redis.
+	Game string `json:"game,omitempty"`
 }
 
 func NewEvent(eventType EventType, data interface{}) (*Event, error) {
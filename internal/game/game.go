@@ -3,9 +3,13 @@ package game
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/reactivejson/cowboys/internal/clock"
 	"github.com/reactivejson/cowboys/internal/domain"
 	"log"
+	"math/rand"
+	"sort"
 	"sync"
+	"time"
 )
 
 // Various error messages
@@ -15,26 +19,120 @@ var (
 	ErrInvalidPayload            = fmt.Errorf("invalid payload")
 	ErrGameFinished              = fmt.Errorf("game is over")
 	ErrInvalidPlayerRegistration = fmt.Errorf("invalid player registration event")
+	ErrGameStalled               = fmt.Errorf("game stalled")
+	ErrUnknownPlayer             = fmt.Errorf("unknown player")
+	ErrReloading                 = fmt.Errorf("reloading")
+	ErrFiringTooFast             = fmt.Errorf("firing faster than the weapon")
+	ErrCoolingDown               = fmt.Errorf("no shot left in the round")
+	ErrInvalidMove               = fmt.Errorf("invalid move")
+	ErrAlreadyActed              = fmt.Errorf("a cowboy either moves or shoots in a round")
+	ErrArenaFull                 = fmt.Errorf("no free tile left in the arena")
+	ErrStunned                   = fmt.Errorf("stunned")
+	ErrNoHealLeft                = fmt.Errorf("no heal left")
+	ErrAlreadyAllied             = fmt.Errorf("already allied")
+	ErrNotAllied                 = fmt.Errorf("not allied")
+	ErrNoProposal                = fmt.Errorf("no alliance proposal to accept")
+	ErrForeignGame               = fmt.Errorf("event of a foreign game")
+	ErrReadyTimeout              = fmt.Errorf("ready check timed out")
 )
 
 type Game struct {
 	gameStarted, gameFinished bool
 	totalPlayers              int
 
-	players map[string]*domain.Player
-	lock    *sync.Mutex
+	// readyCheck requires every registered cowboy to confirm its subscription before the countdown, within
+	// readyTimeout of the roster being full.
+	readyCheck   bool
+	ready        map[string]bool
+	readyTimeout time.Duration
+	readySince   time.Time
+	countdown    time.Duration
+	startAt      time.Time
+	clock        clock.Clock
+	// tolerance is how early a round grants the shots of the cooldown timelines.
+	tolerance time.Duration
+	// shotCooldown is the shortest delay between two shots of any cowboy.
+	shotCooldown time.Duration
+
+	// lockstep collects the shots of a round and resolves them simultaneously on the next emission.
+	lockstep bool
+	round    int
+	pending  map[string][]*domain.Action
+
+	// stall detection counts the rounds in which nobody took damage.
+	stallRounds      int
+	stallPolicy      domain.StallPolicy
+	stalled          int
+	damaged          bool
+	damageMultiplier int
+
+	// grid makes the game positional: cowboys stand on tiles, move, and their shots may miss.
+	grid *domain.Grid
+	rnd  *rand.Rand
+
+	// acted is the kind of action each cowboy took in the round, heals are limited per cowboy.
+	acted      map[string]domain.ActionKind
+	heals      int
+	healPoints int
+
+	// proposals are the alliances offered and not accepted yet.
+	proposals map[proposal]bool
+
+	players    map[string]*domain.Player
+	guns       map[string]*gun
+	eliminated []*domain.Player
+	result     *domain.Result
+	observers  []Observer
+	lock       *sync.Mutex
 }
 
 // NewGame creates a new game state based on the provided configuration.
-
+// An invalid arena must be rejected beforehand, the game is then not positional.
 func NewGame(cfg *domain.MasterConfig) *Game {
+	grid, _ := cfg.Grid()
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	if grid != nil {
+		log.Printf("positional game on a %dx%d grid, seed %d", grid.Width, grid.Height, seed)
+	}
+
 	return &Game{
 		totalPlayers: cfg.Players,
+		readyCheck:   cfg.ReadyCheck,
+		ready:        make(map[string]bool),
+		readyTimeout: cfg.ReadyTimeout,
+		countdown:    cfg.Countdown,
+		clock:        clock.Real{},
+		tolerance:    scheduleTolerance,
+		shotCooldown: cfg.ShotCooldown,
+		lockstep:     cfg.Lockstep,
+		pending:      make(map[string][]*domain.Action),
+		stallRounds:  cfg.StallRounds,
+		stallPolicy:  cfg.StallPolicy,
 		players:      make(map[string]*domain.Player),
+		guns:         make(map[string]*gun),
+		grid:         grid,
+		rnd:          rand.New(rand.NewSource(seed)),
+		acted:        make(map[string]domain.ActionKind),
+		heals:        cfg.Heals,
+		healPoints:   cfg.HealPoints,
+		proposals:    make(map[proposal]bool),
 		lock:         new(sync.Mutex),
 	}
 }
 
+// UseClock replaces the clock of the system the game reads the time from, before the game starts.
+func (gs *Game) UseClock(c clock.Clock) {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	gs.clock = c
+}
+
 // EmitEvent generates an event based on the current game state.
 func (gs *Game) EmitEvent() (*Event, error) {
 	gs.lock.Lock()
@@ -49,12 +147,59 @@ func (gs *Game) EmitEvent() (*Event, error) {
 		return NewEvent(Heartbeat, nil)
 	}
 
-	if len(gs.players) == 1 {
-		gs.gameFinished = true
+	// Ask the cowboys to confirm their subscription until every one of them did, or the time is up.
+	if gs.readyCheck && len(gs.ready) < len(gs.players) {
+		if gs.readyTimeout <= 0 || gs.clock.Now().Before(gs.readySince.Add(gs.readyTimeout)) {
+			return NewEvent(EventReadyCheck, nil)
+		}
+
+		if err := gs.dropUnready(); err != nil {
+			gs.finish(err.Error())
+			return nil, err
+		}
+	}
+
+	now := gs.clock.Now()
+	if gs.startAt.IsZero() {
+		gs.scheduleStart()
+	}
+
+	// Keep announcing the start instant so that every cowboy can synchronise its clock.
+	if now.Before(gs.startAt) {
+		return NewEvent(EventCountdown, &domain.Countdown{
+			StartAt:    gs.startAt,
+			ServerTime: now,
+		})
+	}
+
+	if gs.lockstep {
+		gs.resolveRound()
+	}
+
+	gs.tickEffects()
+
+	if err := gs.checkStall(); err != nil {
+		gs.finish(err.Error())
+		return nil, err
+	}
+
+	gs.round++
+	gs.acted = make(map[string]domain.ActionKind)
+
+	for _, player := range gs.players {
+		gs.reloaded(player)
+		gs.schedule(player)
+	}
+
+	if len(gs.players) <= 1 {
+		gs.finish("")
 	}
 	// Emit a round event with player information.
 	return NewEvent(EventRound, &domain.Round{
-		Players: gs.players,
+		Players:          gs.players,
+		Number:           gs.round,
+		DamageMultiplier: gs.damageMultiplier,
+		Grid:             gs.grid,
 	})
 }
 
@@ -66,8 +211,16 @@ func (gs *Game) HandleEvent(event *Event) error {
 	switch event.Type {
 	case Registration:
 		return gs.handlePlayerRegistration(event)
-	case EventShot:
+	case EventReady:
+		return gs.handlePlayerReady(event)
+	case EventShot, EventAction:
 		return gs.handlePlayerAction(event)
+	case EventReload:
+		return gs.handlePlayerReload(event)
+	case EventMessage:
+		return gs.handlePlayerMessage(event)
+	case EventAlliance:
+		return gs.handlePlayerAlliance(event)
 		// Ignore unsupported events.
 	default:
 		return nil
@@ -89,18 +242,99 @@ func (gs *Game) handlePlayerRegistration(event *Event) error {
 		return ErrInvalidPlayerRegistration
 	}
 
+	if player.Speed < 0 {
+		return fmt.Errorf("%w: speed can not be negative", ErrInvalidPlayerRegistration)
+	}
+
+	if err := gs.arm(&player); err != nil {
+		return err
+	}
+
+	player.MaxHealth = player.Health
+	player.Heals = gs.heals
+	player.Effects = nil
+	player.Allies = nil
+
+	player.Position = nil
+	if gs.grid != nil {
+		if err := gs.spawn(&player); err != nil {
+			return err
+		}
+	}
+
 	gs.players[player.ID] = &player
+	gs.record(Registration, &player)
 
 	if len(gs.players) == gs.totalPlayers {
 		gs.gameStarted = true
+		gs.readySince = gs.clock.Now()
+
+		if !gs.readyCheck {
+			gs.scheduleStart()
+		}
 	}
 
 	return nil
 }
 
+// handlePlayerReady marks a registered player as subscribed and ready for the countdown.
+func (gs *Game) handlePlayerReady(event *Event) error {
+	if !gs.gameStarted {
+		return ErrGameNotStarted
+	}
+
+	var ready domain.Ready
+	if err := json.Unmarshal(event.Data, &ready); err != nil {
+		return fmt.Errorf("failed to unmarshal player ready payload: %w", err)
+	}
+
+	if _, ok := gs.players[ready.ID]; !ok {
+		return ErrInvalidPayload
+	}
+
+	gs.ready[ready.ID] = true
+
+	return nil
+}
+
+// dropUnready eliminates the cowboys that did not confirm their subscription in time, in the order of their IDs.
+// The game starts without them, unless fewer than two cowboys are left to fight.
+func (gs *Game) dropUnready() error {
+	ids := make([]string, 0, len(gs.players))
+	for id := range gs.players {
+		if !gs.ready[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		log.Printf("%s did not confirm its subscription in time, the game starts without it", gs.players[id].Name)
+		gs.eliminate(id, "")
+	}
+
+	if len(gs.players) < 2 {
+		return fmt.Errorf("%w: %d cowboys missing", ErrReadyTimeout, len(ids))
+	}
+
+	return nil
+}
+
+// scheduleStart fixes the start instant once the countdown begins.
+func (gs *Game) scheduleStart() {
+	gs.startAt = gs.clock.Now().Add(gs.countdown)
+	log.Printf("everybody is ready, encounter starts at %s", gs.startAt.Format(time.RFC3339Nano))
+}
+
+// started reports whether the encounter has begun, i.e. the countdown is over.
+func (gs *Game) started() bool {
+	return gs.gameStarted && !gs.startAt.IsZero() && !gs.clock.Now().Before(gs.startAt)
+}
+
 // handlePlayerAction processes a player action event and updates player status.
 func (gs *Game) handlePlayerAction(event *Event) error {
-	if !gs.gameStarted {
+	if !gs.started() {
 		return ErrGameNotStarted
 	}
 
@@ -109,33 +343,77 @@ func (gs *Game) handlePlayerAction(event *Event) error {
 		return fmt.Errorf("failed to unmarshal player action payload: %w", err)
 	}
 
-	if action.Src == "" || action.Dest == "" {
+	if action.Src == "" {
 		return ErrInvalidPayload
 	}
 
-	// Check if the 'action.Src' and 'action.Dest' players exist.
+	if err := action.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	if action.Move != nil && gs.grid == nil {
+		return ErrInvalidMove
+	}
+
+	if gs.lockstep {
+		gs.queueAction(&action)
+		return nil
+	}
+
 	fromPlayer, fromExists := gs.players[action.Src]
+	switch kind := action.Type(); {
+	case !fromExists:
+		return nil
+	case kind == domain.ActionMove:
+		return gs.move(fromPlayer, *action.Move)
+	case kind != domain.ActionShoot:
+		return gs.act(fromPlayer, kind)
+	}
+
+	// Check if the 'action.Src' and 'action.Dest' players exist.
 	toPlayer, toExists := gs.players[action.Dest]
 
-	if !fromExists || !toExists {
-		// One or both players no longer exist.
+	if !toExists {
+		// The target no longer exists.
+		return nil
+	}
+
+	if err := gs.turn(fromPlayer, domain.ActionShoot); err != nil {
+		return err
+	}
+
+	if err := gs.fire(fromPlayer); err != nil {
+		return err
+	}
+
+	gs.acted[fromPlayer.ID] = domain.ActionShoot
+
+	if fromPlayer.IsAlly(toPlayer.ID) {
+		gs.betray(fromPlayer, toPlayer)
+	}
+
+	damage, hit := gs.strike(fromPlayer, toPlayer)
+	if !hit {
 		return nil
 	}
 
 	// Apply the action on the target player.
-	toPlayer.Health -= fromPlayer.Damage
+	toPlayer.Health -= damage
+	gs.damaged = gs.damaged || damage > 0
 
 	log.Printf(
 		"%s Action %d damage on %s",
 		fromPlayer.Name,
-		fromPlayer.Damage,
+		damage,
 		toPlayer.Name,
 	)
 
 	if toPlayer.Health < 1 {
 		// Remove the defeated player from the game.
-		delete(gs.players, action.Dest)
+		gs.eliminate(action.Dest, fromPlayer.Name)
 	}
 
+	gs.onHit(fromPlayer, toPlayer)
+
 	return nil
 }
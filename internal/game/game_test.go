@@ -2,11 +2,37 @@ package game
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/reactivejson/cowboys/internal/clock"
 	"github.com/reactivejson/cowboys/internal/domain"
 )
 
+// newGame creates a game on a virtual clock with the configuration, and registers the cowboys, who fill it.
+func newGame(t *testing.T, cfg *domain.MasterConfig, players ...*domain.Player) *Game {
+	t.Helper()
+
+	cfg.Players = len(players)
+	state := NewGame(cfg)
+	state.UseClock(clock.NewFake(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)))
+
+	for _, player := range players {
+		registration, _ := NewEvent(Registration, player)
+		if err := state.HandleEvent(registration); err != nil {
+			t.Fatalf("unexpected registration err: %v", err)
+		}
+	}
+
+	return state
+}
+
+// virtualClock returns the clock of a game created by newGame, for the test to advance.
+func virtualClock(state *Game) *clock.Fake {
+	return state.clock.(*clock.Fake)
+}
+
 func TestGame(t *testing.T) {
 	state := NewGame(&domain.MasterConfig{
 		Players: 2,
@@ -259,3 +285,233 @@ func TestGameActionEvent(t *testing.T) {
 		t.Fatalf("unexpected change in players' health")
 	}
 }
+
+func TestGameReadyCheckAndCountdown(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{ReadyCheck: true, Countdown: 3 * time.Second},
+		&domain.Player{ID: "test_1", Name: "test_1", Health: 3, Damage: 1},
+		&domain.Player{ID: "test_2", Name: "test_2", Health: 3, Damage: 1},
+	)
+	virtual := virtualClock(state)
+
+	event, err := state.EmitEvent()
+	if err != nil {
+		t.Fatalf("unexpected emission err: %v", err)
+	}
+
+	if event.Type != EventReadyCheck {
+		t.Fatalf("expected event type %q, got %q", EventReadyCheck, event.Type)
+	}
+
+	ready, _ := NewEvent(EventReady, &domain.Ready{ID: "test_1"})
+	if err := state.HandleEvent(ready); err != nil {
+		t.Fatalf("unexpected ready err: %v", err)
+	}
+
+	unknown, _ := NewEvent(EventReady, &domain.Ready{ID: "unknown"})
+	if err := state.HandleEvent(unknown); err != ErrInvalidPayload {
+		t.Fatalf("expected invalid payload error for unknown player, got: %v", err)
+	}
+
+	event, _ = state.EmitEvent()
+	if event.Type != EventReadyCheck {
+		t.Fatalf("expected ready check while a player is not ready, got %q", event.Type)
+	}
+
+	ready, _ = NewEvent(EventReady, &domain.Ready{ID: "test_2"})
+	if err := state.HandleEvent(ready); err != nil {
+		t.Fatalf("unexpected ready err: %v", err)
+	}
+
+	event, _ = state.EmitEvent()
+	if event.Type != EventCountdown {
+		t.Fatalf("expected event type %q, got %q", EventCountdown, event.Type)
+	}
+
+	var countdown domain.Countdown
+	if err := json.Unmarshal(event.Data, &countdown); err != nil {
+		t.Fatalf("can not unmarshal countdown event: %v", err)
+	}
+
+	if !countdown.StartAt.Equal(virtual.Now().Add(3*time.Second)) || !countdown.ServerTime.Equal(virtual.Now()) {
+		t.Fatalf("unexpected countdown %+v", countdown)
+	}
+
+	shot, _ := NewEvent(EventShot, &domain.Action{Src: "test_1", Dest: "test_2"})
+	if err := state.HandleEvent(shot); err != ErrGameNotStarted {
+		t.Fatalf("expected shot during countdown to be rejected, got: %v", err)
+	}
+
+	virtual.Advance(3 * time.Second)
+
+	event, _ = state.EmitEvent()
+	if event.Type != EventRound {
+		t.Fatalf("expected event type %q after countdown, got %q", EventRound, event.Type)
+	}
+
+	if err := state.HandleEvent(shot); err != nil {
+		t.Fatalf("unexpected shot err after countdown: %v", err)
+	}
+}
+
+func TestGameReadyTimeout(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{ReadyCheck: true, ReadyTimeout: time.Second},
+		&domain.Player{ID: "test_1", Name: "test_1", Health: 3, Damage: 1},
+		&domain.Player{ID: "test_2", Name: "test_2", Health: 3, Damage: 1},
+		&domain.Player{ID: "test_3", Name: "test_3", Health: 3, Damage: 1},
+	)
+	virtual := virtualClock(state)
+
+	for _, id := range []string{"test_1", "test_3"} {
+		ready, _ := NewEvent(EventReady, &domain.Ready{ID: id})
+		if err := state.HandleEvent(ready); err != nil {
+			t.Fatalf("unexpected ready err: %v", err)
+		}
+	}
+
+	if event, _ := state.EmitEvent(); event.Type != EventReadyCheck {
+		t.Fatalf("expected ready check before the timeout, got %q", event.Type)
+	}
+
+	virtual.Advance(time.Second)
+
+	event, err := state.EmitEvent()
+	if err != nil {
+		t.Fatalf("unexpected emission err: %v", err)
+	}
+
+	if event.Type != EventRound {
+		t.Fatalf("expected event type %q after the timeout, got %q", EventRound, event.Type)
+	}
+
+	var round domain.Round
+	if err := json.Unmarshal(event.Data, &round); err != nil {
+		t.Fatalf("can not unmarshal round event: %v", err)
+	}
+
+	if len(round.Players) != 2 || round.Players["test_2"] != nil {
+		t.Fatalf("expected the round without test_2, got %+v", round.Players)
+	}
+}
+
+func TestGameReadyTimeoutAborts(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{ReadyCheck: true, ReadyTimeout: time.Second},
+		&domain.Player{ID: "test_1", Name: "test_1", Health: 3, Damage: 1},
+		&domain.Player{ID: "test_2", Name: "test_2", Health: 3, Damage: 1},
+	)
+
+	ready, _ := NewEvent(EventReady, &domain.Ready{ID: "test_1"})
+	if err := state.HandleEvent(ready); err != nil {
+		t.Fatalf("unexpected ready err: %v", err)
+	}
+
+	virtualClock(state).Advance(time.Second)
+
+	if _, err := state.EmitEvent(); !errors.Is(err, ErrReadyTimeout) {
+		t.Fatalf("expected ready timeout error, got: %v", err)
+	}
+
+	if !state.gameFinished || state.Result() == nil || state.Result().Reason == "" {
+		t.Fatalf("expected the game to be aborted with a reason, got %+v", state.Result())
+	}
+}
+
+func TestCountdownLocalStart(t *testing.T) {
+	master := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	countdown := domain.Countdown{
+		StartAt:    master.Add(3 * time.Second),
+		ServerTime: master,
+	}
+
+	// The local clock runs 500ms ahead of the master clock.
+	receivedAt := master.Add(500 * time.Millisecond)
+	if offset := countdown.Offset(receivedAt); offset != 500*time.Millisecond {
+		t.Fatalf("unexpected offset %s", offset)
+	}
+
+	if start := countdown.LocalStart(receivedAt); !start.Equal(master.Add(3500 * time.Millisecond)) {
+		t.Fatalf("unexpected local start %s", start)
+	}
+}
+
+func TestGameJournal(t *testing.T) {
+	state := NewGame(&domain.MasterConfig{
+		Players: 3,
+	})
+
+	var recorded []*Event
+	state.Observe(func(event *Event) {
+		recorded = append(recorded, event)
+	})
+
+	for _, player := range []*domain.Player{
+		{ID: "test_1", Name: "Test1", Health: 3, Damage: 1},
+		{ID: "test_2", Name: "Test2", Health: 1, Damage: 1},
+		{ID: "test_3", Name: "Test3", Health: 1, Damage: 1},
+	} {
+		registration, _ := NewEvent(Registration, player)
+		if err := state.HandleEvent(registration); err != nil {
+			t.Fatalf("unexpected registration err: %v", err)
+		}
+	}
+
+	for _, target := range []string{"test_3", "test_2"} {
+		shot, _ := NewEvent(EventShot, &domain.Action{Src: "test_1", Dest: target})
+		if err := state.HandleEvent(shot); err != nil {
+			t.Fatalf("unexpected shot err: %v", err)
+		}
+	}
+
+	if _, err := state.EmitEvent(); err != nil {
+		t.Fatalf("unexpected emission err: %v", err)
+	}
+
+	expected := []EventType{Registration, Registration, Registration, EventKill, EventKill, EventGameOver}
+	if len(recorded) != len(expected) {
+		t.Fatalf("expected %d recorded events, got %d", len(expected), len(recorded))
+	}
+
+	for i, eventType := range expected {
+		if recorded[i].Type != eventType {
+			t.Fatalf("expected recorded event %d to be %q, got %q", i, eventType, recorded[i].Type)
+		}
+	}
+
+	var result domain.Result
+	if err := json.Unmarshal(recorded[5].Data, &result); err != nil {
+		t.Fatalf("can not unmarshal result: %v", err)
+	}
+
+	if result.Winner == nil || result.Winner.ID != "test_1" || result.Draw {
+		t.Fatalf("expected test_1 to win, got %+v", result)
+	}
+
+	standings := []string{"test_1", "test_2", "test_3"}
+	for i, id := range standings {
+		if result.Standings[i].ID != id {
+			t.Fatalf("expected %s at rank %d, got %s", id, i+1, result.Standings[i].ID)
+		}
+	}
+}
+
+func TestGameStandingsTie(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		state := newGame(t, &domain.MasterConfig{},
+			&domain.Player{ID: "test_3", Name: "Test3", Health: 3, Damage: 1},
+			&domain.Player{ID: "test_1", Name: "Test1", Health: 3, Damage: 1},
+			&domain.Player{ID: "test_4", Name: "Test4", Health: 5, Damage: 1},
+			&domain.Player{ID: "test_2", Name: "Test2", Health: 3, Damage: 1},
+		)
+
+		if err := state.Abort("stopped"); err != nil {
+			t.Fatalf("unexpected abort err: %v", err)
+		}
+
+		// Survivors of equal health are ranked by ID.
+		standings := []string{"test_4", "test_1", "test_2", "test_3"}
+		for rank, id := range standings {
+			if got := state.Result().Standings[rank].ID; got != id {
+				t.Fatalf("expected %s at rank %d, got %s", id, rank+1, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"log"
+	"sort"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// Observe registers an observer notified of every event recorded by the game:
+// registrations, kills, operator actions and the final result.
+func (gs *Game) Observe(observer Observer) {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	gs.observers = append(gs.observers, observer)
+}
+
+// record notifies the observers of an event.
+func (gs *Game) record(eventType EventType, data interface{}) {
+	if len(gs.observers) == 0 {
+		return
+	}
+
+	event, err := NewEvent(eventType, data)
+	if err != nil {
+		log.Printf("record %s event: %v", eventType, err)
+		return
+	}
+
+	for _, observer := range gs.observers {
+		observer(event)
+	}
+}
+
+// eliminate removes a defeated player from the game. shooter is the name of the cowboy who killed it,
+// empty when the rules eliminated the player.
+func (gs *Game) eliminate(id, shooter string) {
+	player, ok := gs.players[id]
+	if !ok {
+		return
+	}
+
+	delete(gs.players, id)
+	gs.eliminated = append(gs.eliminated, player)
+	gs.dissolve(player)
+
+	gs.record(EventKill, &domain.Kill{Shooter: shooter, Target: player.Name})
+}
+
+// finish ends the game and records its result. A non-empty reason means the game was aborted.
+func (gs *Game) finish(reason string) {
+	gs.gameFinished = true
+
+	result := domain.Result{
+		Reason:    reason,
+		Standings: make([]*domain.Player, 0, len(gs.players)+len(gs.eliminated)),
+	}
+
+	for _, player := range gs.players {
+		result.Standings = append(result.Standings, player)
+	}
+
+	// Survivors of equal health are ranked by ID, so that a game always ends with the same standings.
+	sort.SliceStable(result.Standings, func(i, j int) bool {
+		a, b := result.Standings[i], result.Standings[j]
+		if a.Health != b.Health {
+			return a.Health > b.Health
+		}
+
+		return a.ID < b.ID
+	})
+
+	for i := len(gs.eliminated) - 1; i >= 0; i-- {
+		result.Standings = append(result.Standings, gs.eliminated[i])
+	}
+
+	switch {
+	case reason != "":
+	case len(gs.players) == 1:
+		result.Winner = result.Standings[0]
+	default:
+		result.Draw = true
+	}
+
+	gs.result = &result
+	gs.record(EventGameOver, &result)
+}
+
+// Result returns the outcome of the game, nil while it is not over.
+func (gs *Game) Result() *domain.Result {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	return gs.result
+}
+
+// Abort ends the game without a winner.
+func (gs *Game) Abort(reason string) error {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	if gs.gameFinished {
+		return ErrGameFinished
+	}
+
+	log.Printf("game aborted: %s", reason)
+	gs.finish(reason)
+
+	return nil
+}
+
+// Snapshot returns a copy of the game state.
+func (gs *Game) Snapshot() *domain.State {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	state := &domain.State{
+		Phase:      gs.phase(),
+		Round:      gs.round,
+		Players:    make([]*domain.Player, 0, len(gs.players)),
+		Eliminated: make([]*domain.Player, 0, len(gs.eliminated)),
+		StartAt:    gs.startAt,
+	}
+
+	for _, player := range gs.players {
+		state.Players = append(state.Players, snapshot(player))
+	}
+
+	sort.Slice(state.Players, func(i, j int) bool {
+		return state.Players[i].ID < state.Players[j].ID
+	})
+
+	for _, player := range gs.eliminated {
+		state.Eliminated = append(state.Eliminated, snapshot(player))
+	}
+
+	return state
+}
+
+// snapshot copies a player, with its weapon, position, allies and effects.
+func snapshot(player *domain.Player) *domain.Player {
+	copied := *player
+	if player.Weapon != nil {
+		weapon := *player.Weapon
+		copied.Weapon = &weapon
+	}
+
+	if player.Position != nil {
+		position := *player.Position
+		copied.Position = &position
+	}
+
+	copied.Allies = append([]string(nil), player.Allies...)
+
+	copied.Effects = nil
+	for _, effect := range player.Effects {
+		effect := *effect
+		copied.Effects = append(copied.Effects, &effect)
+	}
+
+	return &copied
+}
+
+func (gs *Game) phase() domain.Phase {
+	switch {
+	case gs.gameFinished:
+		return domain.PhaseFinished
+	case !gs.gameStarted:
+		return domain.PhaseWaiting
+	case gs.startAt.IsZero():
+		return domain.PhaseReadyCheck
+	case gs.clock.Now().Before(gs.startAt):
+		return domain.PhaseCountdown
+	default:
+		return domain.PhaseFighting
+	}
+}
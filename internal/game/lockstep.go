@@ -0,0 +1,160 @@
+package game
+
+import (
+	"log"
+	"sort"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// queueAction keeps the latest actions of a living cowboy for the current round, as many as the shots
+// it has in the round, or one without a speed. Any other action replaces the shots of the round and the
+// other way round. Actions answering another round are stale and dropped.
+func (gs *Game) queueAction(action *domain.Action) {
+	if action.Round != gs.round {
+		return
+	}
+
+	player, ok := gs.players[action.Src]
+	if !ok {
+		return
+	}
+
+	queued, limit := gs.pending[action.Src], 1
+	if player.Speed > 0 {
+		limit = player.Shots
+	}
+
+	switch {
+	case action.Type() != domain.ActionShoot:
+		gs.pending[action.Src] = []*domain.Action{action}
+		return
+	case limit < 1:
+		return
+	case len(queued) > 0 && queued[0].Type() != domain.ActionShoot:
+		queued = nil
+	case len(queued) == limit:
+		queued = queued[1:]
+	}
+
+	gs.pending[action.Src] = append(queued, action)
+}
+
+// resolveRound applies every queued action at once and opens the next round.
+// Dodges, aims, heals and covers come first, so they protect from the shots of the round.
+// Damage is computed from the state at the start of the round, so cowboys can kill each other
+// in the same round and the game can end in a draw. The damage is dealt in the order of the IDs
+// of the cowboys, so that the dead are eliminated in the same order for a round. The moves are
+// applied after the shots, in the order of the IDs of the cowboys when two of them step to the
+// same tile. The effects of the hits apply once the damage is dealt, so they do not change the
+// outcome of the round.
+func (gs *Game) resolveRound() {
+	damages := make(map[string]int)
+	killers := make(map[string]string)
+	var shots, moves, hits []*domain.Action
+
+	for _, action := range gs.queued() {
+		switch kind := action.Type(); kind {
+		case domain.ActionShoot:
+			shots = append(shots, action)
+		case domain.ActionMove:
+			moves = append(moves, action)
+		default:
+			if player, ok := gs.players[action.Src]; ok {
+				if err := gs.act(player, kind); err != nil {
+					log.Printf("%s can not %s: %v", player.Name, kind, err)
+				}
+			}
+		}
+	}
+
+	for _, action := range shots {
+
+		fromPlayer, fromExists := gs.players[action.Src]
+		toPlayer, toExists := gs.players[action.Dest]
+
+		if !fromExists || !toExists || action.Src == action.Dest {
+			continue
+		}
+
+		if err := gs.fire(fromPlayer); err != nil {
+			log.Printf("%s can not shoot: %v", fromPlayer.Name, err)
+			continue
+		}
+
+		if fromPlayer.IsAlly(toPlayer.ID) {
+			gs.betray(fromPlayer, toPlayer)
+		}
+
+		damage, hit := gs.strike(fromPlayer, toPlayer)
+		if !hit {
+			continue
+		}
+
+		damages[action.Dest] += damage
+		killers[action.Dest] = fromPlayer.Name
+		hits = append(hits, action)
+
+		log.Printf(
+			"%s Action %d damage on %s",
+			fromPlayer.Name,
+			damage,
+			toPlayer.Name,
+		)
+	}
+
+	ids := make([]string, 0, len(damages))
+	for id := range damages {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		player, damage := gs.players[id], damages[id]
+		player.Health -= damage
+		gs.damaged = gs.damaged || damage > 0
+
+		if player.Health < 1 {
+			gs.eliminate(id, killers[id])
+		}
+	}
+
+	for _, action := range hits {
+		if fromPlayer, ok := gs.players[action.Src]; ok {
+			gs.onHit(fromPlayer, gs.players[action.Dest])
+		}
+	}
+
+	for _, action := range moves {
+		if player, ok := gs.players[action.Src]; ok {
+			if err := gs.move(player, *action.Move); err != nil {
+				log.Printf("%s can not move to %s: %v", player.Name, action.Move, err)
+			}
+		}
+	}
+
+	if len(gs.players) == 0 {
+		log.Printf("round %d ended in a draw, no cowboy is standing", gs.round)
+	}
+
+	gs.pending = make(map[string][]*domain.Action)
+}
+
+// queued flattens the pending actions of the round in the order of the IDs of the cowboys,
+// so that the rolls of a positional game are the same for a seed.
+func (gs *Game) queued() []*domain.Action {
+	ids := make([]string, 0, len(gs.pending))
+	for id := range gs.pending {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	var actions []*domain.Action
+	for _, id := range ids {
+		actions = append(actions, gs.pending[id]...)
+	}
+
+	return actions
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+func emitRound(t *testing.T, state *Game) *domain.Round {
+	t.Helper()
+
+	event, err := state.EmitEvent()
+	if err != nil {
+		t.Fatalf("unexpected emission err: %v", err)
+	}
+
+	if event.Type != EventRound {
+		t.Fatalf("expected event type %q, got %q", EventRound, event.Type)
+	}
+
+	var round domain.Round
+	if err := json.Unmarshal(event.Data, &round); err != nil {
+		t.Fatalf("can not unmarshal round event: %v", err)
+	}
+
+	return &round
+}
+
+func shoot(t *testing.T, state *Game, from, to string, round int) {
+	t.Helper()
+
+	shot, _ := NewEvent(EventShot, &domain.Action{Src: from, Dest: to, Round: round})
+	if err := state.HandleEvent(shot); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+}
+
+func TestGameLockstepDraw(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{Lockstep: true},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 1, Damage: 1},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 1, Damage: 1},
+	)
+
+	round := emitRound(t, state)
+	if round.Number != 1 || len(round.Players) != 2 {
+		t.Fatalf("unexpected first round %+v", round)
+	}
+
+	// Shots are queued until the deadline, so the second shot is not discarded by the first kill.
+	shoot(t, state, "test_1", "test_2", round.Number)
+	shoot(t, state, "test_2", "test_1", round.Number)
+
+	round = emitRound(t, state)
+	if round.Number != 2 || len(round.Players) != 0 {
+		t.Fatalf("expected a draw in round 2, got %+v", round)
+	}
+
+	if _, err := state.EmitEvent(); err != ErrGameFinished {
+		t.Fatalf("expected ErrGameFinished after a draw, got: %v", err)
+	}
+}
+
+func TestGameLockstepEliminationOrder(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		state := newGame(t, &domain.MasterConfig{Lockstep: true},
+			&domain.Player{ID: "test_1", Name: "Test1", Health: 1, Damage: 1},
+			&domain.Player{ID: "test_2", Name: "Test2", Health: 1, Damage: 1},
+			&domain.Player{ID: "test_3", Name: "Test3", Health: 1, Damage: 1},
+		)
+
+		var kills []string
+		state.Observe(func(event *Event) {
+			var kill domain.Kill
+			if event.Type == EventKill && json.Unmarshal(event.Data, &kill) == nil {
+				kills = append(kills, kill.Target)
+			}
+		})
+
+		round := emitRound(t, state)
+		shoot(t, state, "test_1", "test_3", round.Number)
+		shoot(t, state, "test_2", "test_1", round.Number)
+		shoot(t, state, "test_3", "test_2", round.Number)
+		emitRound(t, state)
+
+		// The cowboys killed in the same round are eliminated in the order of their IDs.
+		if len(kills) != 3 || kills[0] != "Test1" || kills[1] != "Test2" || kills[2] != "Test3" {
+			t.Fatalf("expected the kills in the order of the IDs, got %v", kills)
+		}
+	}
+}
+
+func TestGameLockstepResolution(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{Lockstep: true},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 5, Damage: 2},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 5, Damage: 1},
+		&domain.Player{ID: "test_3", Name: "Test3", Health: 5, Damage: 3},
+	)
+
+	round := emitRound(t, state)
+
+	// A stale shot and a shot from an unknown cowboy are dropped.
+	shoot(t, state, "test_3", "test_1", round.Number-1)
+	shoot(t, state, "unknown", "test_1", round.Number)
+
+	// The latest action of a cowboy in a round wins.
+	shoot(t, state, "test_1", "test_3", round.Number)
+	shoot(t, state, "test_1", "test_2", round.Number)
+	shoot(t, state, "test_3", "test_2", round.Number)
+
+	round = emitRound(t, state)
+	if round.Players["test_1"].Health != 5 || round.Players["test_3"].Health != 5 {
+		t.Fatalf("unexpected change in players' health: %+v", round.Players)
+	}
+
+	if _, ok := round.Players["test_2"]; ok {
+		t.Fatalf("expected test_2 to be killed by simultaneous shots")
+	}
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"log"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// spawnAttempts is the number of random tiles tried before looking for a free one tile by tile.
+const spawnAttempts = 100
+
+// spawn places a registering cowboy on a random free tile of the grid.
+func (gs *Game) spawn(player *domain.Player) error {
+	for i := 0; i < spawnAttempts; i++ {
+		tile := domain.Position{X: gs.rnd.Intn(gs.grid.Width), Y: gs.rnd.Intn(gs.grid.Height)}
+		if gs.free(tile) {
+			player.Position = &tile
+			return nil
+		}
+	}
+
+	for x := 0; x < gs.grid.Width; x++ {
+		for y := 0; y < gs.grid.Height; y++ {
+			if tile := (domain.Position{X: x, Y: y}); gs.free(tile) {
+				player.Position = &tile
+				return nil
+			}
+		}
+	}
+
+	return ErrArenaFull
+}
+
+// free reports whether a cowboy can spawn on the tile: it is neither a cover tile nor occupied.
+func (gs *Game) free(tile domain.Position) bool {
+	return !gs.grid.IsCover(tile) && gs.occupant(tile) == nil
+}
+
+// occupant returns the cowboy standing on the tile, if any.
+func (gs *Game) occupant(tile domain.Position) *domain.Player {
+	for _, player := range gs.players {
+		if player.Position != nil && *player.Position == tile {
+			return player
+		}
+	}
+
+	return nil
+}
+
+// move steps the player to an adjacent free tile, cover included.
+func (gs *Game) move(player *domain.Player, to domain.Position) error {
+	if err := gs.turn(player, domain.ActionMove); err != nil {
+		return err
+	}
+
+	if !gs.grid.Contains(to) || !player.Position.Adjacent(to) || gs.occupant(to) != nil {
+		return ErrInvalidMove
+	}
+
+	player.Position = &to
+	gs.acted[player.ID] = domain.ActionMove
+
+	log.Printf("%s moves to %s", player.Name, to)
+
+	return nil
+}
+
+// hits rolls whether the shot of a player reaches its target. Without a grid, shots always hit
+// a cowboy who does not dodge.
+func (gs *Game) hits(from, to *domain.Player) bool {
+	chance := 1.0
+	if gs.grid != nil {
+		chance = gs.grid.HitChance(*from.Position, *to.Position)
+	}
+
+	if effectOf(to, domain.EffectDodge) != nil {
+		chance *= domain.DodgeHitChance
+	}
+
+	if gs.grid == nil && chance >= 1 || gs.rnd.Float64() < chance {
+		return true
+	}
+
+	log.Printf("%s misses %s", from.Name, to.Name)
+
+	return false
+}
@@ -0,0 +1,171 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+func move(state *Game, id string, x, y int) error {
+	action, _ := NewEvent(EventShot, &domain.Action{Src: id, Move: &domain.Position{X: x, Y: y}})
+	return state.HandleEvent(action)
+}
+
+func TestGameSpawn(t *testing.T) {
+	cfg := &domain.MasterConfig{ArenaSize: "2x2", ArenaCover: []string{"0:0"}, Seed: 7}
+	players := []*domain.Player{
+		{ID: "test_1", Name: "Test1", Health: 10, Damage: 1},
+		{ID: "test_2", Name: "Test2", Health: 10, Damage: 1},
+		{ID: "test_3", Name: "Test3", Health: 10, Damage: 1},
+	}
+
+	state := newGame(t, cfg, players...)
+	round := emitRound(t, state)
+
+	if round.Grid == nil || round.Grid.Width != 2 {
+		t.Fatalf("expected the grid in the round, got %+v", round.Grid)
+	}
+
+	tiles := make(map[domain.Position]bool)
+	for id, player := range round.Players {
+		if player.Position == nil || !round.Grid.Contains(*player.Position) || round.Grid.IsCover(*player.Position) {
+			t.Fatalf("%s spawned on %v", id, player.Position)
+		}
+
+		tiles[*player.Position] = true
+	}
+
+	if len(tiles) != 3 {
+		t.Fatalf("expected every cowboy on its own tile, got %v", tiles)
+	}
+
+	// The spawns only depend on the seed.
+	again := emitRound(t, newGame(t, cfg, players...))
+	for id, player := range round.Players {
+		if *again.Players[id].Position != *player.Position {
+			t.Fatalf("expected %s to spawn on %v again, got %v", id, player.Position, again.Players[id].Position)
+		}
+	}
+
+	full := NewGame(&domain.MasterConfig{Players: 4, ArenaSize: "2x2", ArenaCover: []string{"0:0"}})
+	for _, player := range append(players, &domain.Player{ID: "test_4", Name: "Test4", Health: 10, Damage: 1}) {
+		registration, _ := NewEvent(Registration, player)
+		if err := full.HandleEvent(registration); err != nil && err != ErrArenaFull {
+			t.Fatalf("unexpected registration err: %v", err)
+		} else if err == ErrArenaFull && player.ID != "test_4" {
+			t.Fatalf("expected the arena to be full for test_4 only, got it for %s", player.ID)
+		}
+	}
+}
+
+func TestGameMove(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{ArenaSize: "10x10", Seed: 1},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 10, Damage: 1},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 10, Damage: 1},
+	)
+	emitRound(t, state)
+
+	state.players["test_1"].Position = &domain.Position{X: 0, Y: 0}
+	state.players["test_2"].Position = &domain.Position{X: 1, Y: 1}
+
+	for _, tile := range []domain.Position{{X: 2, Y: 0}, {X: -1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}} {
+		if err := move(state, "test_1", tile.X, tile.Y); err != ErrInvalidMove {
+			t.Fatalf("expected ErrInvalidMove to %s, got: %v", tile, err)
+		}
+	}
+
+	if err := move(state, "test_1", 1, 0); err != nil {
+		t.Fatalf("unexpected move err: %v", err)
+	}
+
+	// A cowboy either moves or shoots in a round.
+	if err := fire(state, "test_1", "test_2"); err != ErrAlreadyActed {
+		t.Fatalf("expected ErrAlreadyActed shooting after a move, got: %v", err)
+	}
+
+	if err := fire(state, "test_2", "test_1"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	if err := move(state, "test_2", 2, 2); err != ErrAlreadyActed {
+		t.Fatalf("expected ErrAlreadyActed moving after a shot, got: %v", err)
+	}
+
+	round := emitRound(t, state)
+	if *round.Players["test_1"].Position != (domain.Position{X: 1, Y: 0}) {
+		t.Fatalf("expected test_1 on 1:0, got %s", round.Players["test_1"].Position)
+	}
+
+	// Point blank, the shot of test_2 could not miss.
+	if round.Players["test_1"].Health != 9 {
+		t.Fatalf("expected test_1 to be hit, got %d health", round.Players["test_1"].Health)
+	}
+
+	if err := move(state, "test_1", 0, 0); err != nil {
+		t.Fatalf("unexpected move err in a new round: %v", err)
+	}
+
+	plain := newGame(t, &domain.MasterConfig{},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 10, Damage: 1},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 10, Damage: 1},
+	)
+	emitRound(t, plain)
+
+	if err := move(plain, "test_1", 0, 0); err != ErrInvalidMove {
+		t.Fatalf("expected ErrInvalidMove without a grid, got: %v", err)
+	}
+}
+
+func TestGameHitChance(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{ArenaSize: "30x1", ArenaCover: []string{"29:0"}, Seed: 3},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 1000, Damage: 1},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 1000, Damage: 1},
+	)
+	emitRound(t, state)
+
+	// Across the arena and behind cover, only one shot in twenty hits.
+	state.players["test_1"].Position = &domain.Position{X: 0, Y: 0}
+	state.players["test_2"].Position = &domain.Position{X: 29, Y: 0}
+
+	for i := 0; i < 1000; i++ {
+		if err := fire(state, "test_1", "test_2"); err != nil {
+			t.Fatalf("unexpected shot err: %v", err)
+		}
+	}
+
+	if hits := 1000 - state.players["test_2"].Health; hits < 25 || hits > 80 {
+		t.Fatalf("expected about 50 hits, got %d", hits)
+	}
+}
+
+func TestGameLockstepMove(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{ArenaSize: "10x10", Lockstep: true, Seed: 1},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 10, Damage: 1},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 10, Damage: 1},
+		&domain.Player{ID: "test_3", Name: "Test3", Health: 10, Damage: 1},
+	)
+	round := emitRound(t, state)
+
+	state.players["test_1"].Position = &domain.Position{X: 0, Y: 0}
+	state.players["test_2"].Position = &domain.Position{X: 1, Y: 0}
+	state.players["test_3"].Position = &domain.Position{X: 2, Y: 2}
+
+	// test_1 shoots from where test_2 stood at the start of the round, and test_2 and test_3 race to the same tile.
+	shoot(t, state, "test_1", "test_2", round.Number)
+	for _, id := range []string{"test_2", "test_3"} {
+		action, _ := NewEvent(EventShot, &domain.Action{Src: id, Move: &domain.Position{X: 1, Y: 1}, Round: round.Number})
+		if err := state.HandleEvent(action); err != nil {
+			t.Fatalf("unexpected move err: %v", err)
+		}
+	}
+
+	round = emitRound(t, state)
+
+	if health := round.Players["test_2"].Health; health != 9 {
+		t.Fatalf("expected test_2 to be hit before moving, got %d health", health)
+	}
+
+	if *round.Players["test_2"].Position != (domain.Position{X: 1, Y: 1}) || *round.Players["test_3"].Position != (domain.Position{X: 2, Y: 2}) {
+		t.Fatalf("expected test_2 to take the tile first, got %s and %s", round.Players["test_2"].Position, round.Players["test_3"].Position)
+	}
+}
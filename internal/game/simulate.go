@@ -0,0 +1,188 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/clock"
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// Targeter picks the cowboy that self shoots at in a round, like the strategies of the players.
+type Targeter interface {
+	Target(ctx context.Context, self string, round *domain.Round) (string, error)
+}
+
+// Decider is a Targeter that decides the whole action of the cowboy, to move in a positional game.
+type Decider interface {
+	Decide(ctx context.Context, self string, round *domain.Round) (*domain.Action, error)
+}
+
+// SimulationConfig describes a game played offline.
+type SimulationConfig struct {
+	// Players fight in the simulation. A cowboy without a speed shoots once every ShotCooldown, a second
+	// when it is not set.
+	Players      []*domain.Player
+	Targeter     Targeter
+	ShotCooldown time.Duration
+	// MaxRounds aborts a game lasting longer, 0 means no limit.
+	MaxRounds   int
+	StallRounds int
+	StallPolicy domain.StallPolicy
+	// Grid makes the game positional, Seed decides the spawns and the hits.
+	Grid *domain.Grid
+	Seed int64
+}
+
+// Simulation is the outcome of a simulated game.
+type Simulation struct {
+	Result *domain.Result
+	Rounds int
+	// Elapsed is the time the game took on the virtual clock.
+	Elapsed time.Duration
+}
+
+// Simulate plays a game offline on a virtual clock. Instead of a round every tick, a round is emitted
+// at each instant a cowboy is due to shoot on its cooldown timeline, and every cowboy shoots the shots
+// the round grants it right away. A game of any length takes no time and, for a deterministic targeter,
+// ends the same way every time.
+func Simulate(ctx context.Context, cfg *SimulationConfig) (*Simulation, error) {
+	gs := NewGame(&domain.MasterConfig{
+		Players:     len(cfg.Players),
+		StallRounds: cfg.StallRounds,
+		StallPolicy: cfg.StallPolicy,
+		Seed:        cfg.Seed,
+	})
+	gs.grid = cfg.Grid
+
+	start := time.Unix(0, 0).UTC()
+	virtual, elapsed := clock.NewFake(start), time.Duration(0)
+	gs.clock = virtual
+	// Rounds are emitted right when the shots are due.
+	gs.tolerance = 0
+
+	speed := 1.0
+	if cfg.ShotCooldown > 0 {
+		speed = float64(time.Second) / float64(cfg.ShotCooldown)
+	}
+
+	for _, player := range cfg.Players {
+		cowboy := *player
+		if cowboy.Speed == 0 {
+			cowboy.Speed = speed
+		}
+
+		registration, err := NewEvent(Registration, &cowboy)
+		if err != nil {
+			return nil, fmt.Errorf("create registration event: %w", err)
+		}
+
+		if err := gs.HandleEvent(registration); err != nil {
+			return nil, fmt.Errorf("register %s: %w", player.Name, err)
+		}
+	}
+
+	if !gs.gameStarted {
+		return nil, fmt.Errorf("%w: a game needs cowboys", ErrInvalidPlayerRegistration)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if cfg.MaxRounds > 0 && gs.round == cfg.MaxRounds {
+			if err := gs.Abort(fmt.Sprintf("no winner after %d rounds", gs.round)); err != nil {
+				return nil, err
+			}
+		}
+
+		event, err := gs.EmitEvent()
+		switch {
+		case errors.Is(err, ErrGameFinished), errors.Is(err, ErrGameStalled):
+			return &Simulation{Result: gs.Result(), Rounds: gs.round, Elapsed: elapsed}, nil
+		case err != nil:
+			return nil, err
+		case gs.Result() != nil:
+			// The last round only shows the survivor.
+			return &Simulation{Result: gs.Result(), Rounds: gs.round, Elapsed: elapsed}, nil
+		}
+
+		var round domain.Round
+		if err := json.Unmarshal(event.Data, &round); err != nil {
+			return nil, fmt.Errorf("unmarshal round: %w", err)
+		}
+
+		if err := simulateRound(ctx, gs, cfg.Targeter, &round); err != nil {
+			return nil, err
+		}
+
+		elapsed = virtual.Now().Sub(start)
+		virtual.Advance(gs.nextShot().Sub(virtual.Now()))
+	}
+}
+
+// simulateRound fires the shots the round grants the cowboys, or moves them, in the order of their IDs.
+func simulateRound(ctx context.Context, gs *Game, targeter Targeter, round *domain.Round) error {
+	ids := make([]string, 0, len(round.Players))
+	for id := range round.Players {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if round.Players[id].Reloading {
+			continue
+		}
+
+		for shot := 0; shot < round.Players[id].Shots; shot++ {
+			action, err := decide(ctx, targeter, id, round)
+			if err != nil {
+				return fmt.Errorf("%s decides: %w", round.Players[id].Name, err)
+			}
+
+			action.Round = round.Number
+
+			event, err := NewEvent(EventAction, action)
+			if err != nil {
+				return fmt.Errorf("create action event: %w", err)
+			}
+
+			// The actions are checked like in a real game: a cowboy emptying its magazine in the round starts
+			// reloading, a cowboy can not step to a tile another one just took nor act after shooting.
+			err = gs.HandleEvent(event)
+			switch {
+			case errors.Is(err, ErrReloading), errors.Is(err, ErrFiringTooFast),
+				errors.Is(err, ErrInvalidMove), errors.Is(err, ErrAlreadyActed), errors.Is(err, ErrStunned),
+				errors.Is(err, ErrNoHealLeft):
+			case err != nil:
+				return fmt.Errorf("%s acts: %w", round.Players[id].Name, err)
+			}
+
+			if action.Type() != domain.ActionShoot {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// decide asks the targeter for the action of the cowboy, a shot unless it is a Decider.
+func decide(ctx context.Context, targeter Targeter, self string, round *domain.Round) (*domain.Action, error) {
+	if decider, ok := targeter.(Decider); ok {
+		return decider.Decide(ctx, self, round)
+	}
+
+	target, err := targeter.Target(ctx, self, round)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Action{Src: self, Dest: target}, nil
+}
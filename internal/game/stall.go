@@ -0,0 +1,110 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// checkStall counts the rounds that ended without damage and applies the stall policy
+// once the threshold is reached. It must be called before a new round is opened.
+func (gs *Game) checkStall() error {
+	if gs.damageMultiplier > 0 {
+		// Sudden death escalates every round until the game is over.
+		gs.damageMultiplier++
+	}
+
+	if gs.round == 0 {
+		// Nobody could shoot before the first round.
+		return nil
+	}
+
+	if gs.damaged {
+		gs.stalled = 0
+	} else {
+		gs.stalled++
+	}
+
+	gs.damaged = false
+
+	if gs.stallRounds < 1 || gs.stalled < gs.stallRounds {
+		return nil
+	}
+
+	switch gs.stallPolicy {
+	case domain.StallHighestHealth:
+		gs.keepHealthiest()
+	case domain.StallSuddenDeath:
+		if gs.damageMultiplier == 0 {
+			log.Printf("no damage for %d rounds, sudden death", gs.stalled)
+			gs.damageMultiplier = 2
+		}
+	case domain.StallAbort:
+		return fmt.Errorf("%w: no damage for %d rounds", ErrGameStalled, gs.stalled)
+	case domain.StallWait:
+	default:
+		return fmt.Errorf("%w: unknown stall policy %q", ErrGameStalled, gs.stallPolicy)
+	}
+
+	return nil
+}
+
+// keepHealthiest removes every cowboy but the one with the highest health.
+// A tie for the highest health removes everybody and the game ends in a draw.
+func (gs *Game) keepHealthiest() {
+	if len(gs.players) == 0 {
+		return
+	}
+
+	var (
+		winner *domain.Player
+		tie    bool
+	)
+
+	for _, player := range gs.players {
+		switch {
+		case winner == nil || player.Health > winner.Health:
+			winner, tie = player, false
+		case player.Health == winner.Health:
+			tie = true
+		}
+	}
+
+	for id := range gs.players {
+		if tie || id != winner.ID {
+			gs.eliminate(id, "")
+		}
+	}
+
+	if tie {
+		log.Printf("no damage for %d rounds, tie for the highest health is a draw", gs.stalled)
+		return
+	}
+
+	log.Printf("no damage for %d rounds, %s wins with the highest health", gs.stalled, winner.Name)
+}
+
+// damage returns the damage a shot of the player deals to the target: raised by the damage buff
+// and the aim of the shooter, escalated in sudden death, lowered by the defense buff of the target,
+// then halved, rounded up, when the target took cover.
+func (gs *Game) damage(from, to *domain.Player) int {
+	damage := from.Damage + strength(from, domain.EffectDamageBuff)
+	if effectOf(from, domain.EffectAim) != nil {
+		damage *= domain.AimMultiplier
+	}
+
+	if gs.damageMultiplier > 0 {
+		damage *= gs.damageMultiplier
+	}
+
+	if damage -= strength(to, domain.EffectDefenseBuff); damage < 0 {
+		return 0
+	}
+
+	if effectOf(to, domain.EffectCover) != nil {
+		damage = (damage + 1) / 2
+	}
+
+	return damage
+}
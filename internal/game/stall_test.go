@@ -0,0 +1,84 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// stalling are two cowboys too sturdy to end a game in two rounds.
+func stalling() []*domain.Player {
+	return []*domain.Player{
+		{ID: "test_1", Name: "Test1", Health: 10, Damage: 1},
+		{ID: "test_2", Name: "Test2", Health: 8, Damage: 1},
+	}
+}
+
+func TestGameStallAbort(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{StallRounds: 2, StallPolicy: domain.StallAbort}, stalling()...)
+
+	emitRound(t, state)
+	shoot(t, state, "test_1", "test_2", 0)
+	emitRound(t, state)
+
+	// Two rounds without damage.
+	emitRound(t, state)
+
+	if _, err := state.EmitEvent(); !errors.Is(err, ErrGameStalled) {
+		t.Fatalf("expected ErrGameStalled, got: %v", err)
+	}
+
+	if _, err := state.EmitEvent(); err != ErrGameFinished {
+		t.Fatalf("expected ErrGameFinished after abort, got: %v", err)
+	}
+}
+
+func TestGameStallWait(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{StallRounds: 2, StallPolicy: domain.StallWait}, stalling()...)
+
+	for i := 0; i < 5; i++ {
+		if round := emitRound(t, state); len(round.Players) != 2 {
+			t.Fatalf("expected the game to keep waiting, got %+v", round)
+		}
+	}
+}
+
+func TestGameStallHighestHealth(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{StallRounds: 2, StallPolicy: domain.StallHighestHealth}, stalling()...)
+
+	emitRound(t, state)
+	emitRound(t, state)
+
+	round := emitRound(t, state)
+	if _, ok := round.Players["test_1"]; !ok || len(round.Players) != 1 {
+		t.Fatalf("expected test_1 to win with the highest health, got %+v", round.Players)
+	}
+
+	if _, err := state.EmitEvent(); err != ErrGameFinished {
+		t.Fatalf("expected ErrGameFinished, got: %v", err)
+	}
+}
+
+func TestGameStallSuddenDeath(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{StallRounds: 2, StallPolicy: domain.StallSuddenDeath}, stalling()...)
+
+	emitRound(t, state)
+	emitRound(t, state)
+
+	round := emitRound(t, state)
+	if round.DamageMultiplier != 2 {
+		t.Fatalf("expected sudden death damage multiplier 2, got %d", round.DamageMultiplier)
+	}
+
+	shoot(t, state, "test_1", "test_2", round.Number)
+
+	round = emitRound(t, state)
+	if round.Players["test_2"].Health != 6 {
+		t.Fatalf("expected escalated damage, got health %d", round.Players["test_2"].Health)
+	}
+
+	if round.DamageMultiplier != 3 {
+		t.Fatalf("expected sudden death damage multiplier 3, got %d", round.DamageMultiplier)
+	}
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+const (
+	// maxShotsPerRound bounds the shots a round grants, so that a long pause does not turn into a burst.
+	maxShotsPerRound = 10
+	// scheduleTolerance absorbs the jitter of the ticker of the master: a shot due that much after a round
+	// belongs to it, so that a master ticking at the speed of a cowboy grants it one shot every round.
+	scheduleTolerance = 100 * time.Millisecond
+)
+
+// schedule grants the player the shots of its cooldown timeline that fell due since the previous round.
+// The timeline starts with the first round and shots that were not fired in their round are lost.
+// A cowboy without a speed is not scheduled and the master takes its shots as they come.
+func (gs *Game) schedule(player *domain.Player) {
+	if player.Speed <= 0 {
+		return
+	}
+
+	gun, now, cooldown := gs.guns[player.ID], gs.clock.Now(), gs.cooldown(player)
+	if gun.nextShot.IsZero() {
+		gun.nextShot = now
+	}
+
+	tolerance := gs.tolerance
+	if tolerance > cooldown/2 {
+		tolerance = cooldown / 2
+	}
+
+	player.Shots = 0
+	for !gun.nextShot.After(now.Add(tolerance)) {
+		if player.Shots == maxShotsPerRound {
+			gun.nextShot = now.Add(cooldown)
+			break
+		}
+
+		player.Shots++
+		gun.nextShot = gun.nextShot.Add(cooldown)
+	}
+}
+
+// nextShot returns the earliest instant a living cowboy with a speed is due to shoot, zero if there is none.
+func (gs *Game) nextShot() time.Time {
+	var next time.Time
+	for id, player := range gs.players {
+		if player.Speed <= 0 {
+			continue
+		}
+
+		if at := gs.guns[id].nextShot; next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+
+	return next
+}
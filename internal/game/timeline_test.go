@@ -0,0 +1,162 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/strategy"
+)
+
+func TestGameSpeed(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{},
+		&domain.Player{ID: "fast", Name: "Fast", Health: 10, Damage: 1, Speed: 2},
+		&domain.Player{ID: "slow", Name: "Slow", Health: 10, Damage: 1, Speed: 0.5},
+		&domain.Player{ID: "legacy", Name: "Legacy", Health: 10, Damage: 1},
+	)
+	virtual := virtualClock(state)
+
+	// Every cowboy shoots in the first round, then follows its own timeline.
+	expected := []map[string]int{
+		{"fast": 1, "slow": 1},
+		{"fast": 2, "slow": 0},
+		{"fast": 2, "slow": 1},
+		{"fast": 2, "slow": 0},
+	}
+
+	for number, shots := range expected {
+		round := emitRound(t, state)
+		for id, count := range shots {
+			if round.Players[id].Shots != count {
+				t.Fatalf("round %d: expected %d shots for %s, got %d", number+1, count, id, round.Players[id].Shots)
+			}
+		}
+
+		if round.Players["legacy"].Shots != 0 {
+			t.Fatalf("round %d: a cowboy without a speed is not scheduled", number+1)
+		}
+
+		for i := 0; i < shots["fast"]; i++ {
+			if err := fire(state, "fast", "legacy"); err != nil {
+				t.Fatalf("round %d: unexpected shot err: %v", number+1, err)
+			}
+		}
+
+		if err := fire(state, "fast", "legacy"); err != ErrCoolingDown {
+			t.Fatalf("round %d: expected ErrCoolingDown, got: %v", number+1, err)
+		}
+
+		if shots["slow"] == 0 {
+			if err := fire(state, "slow", "legacy"); err != ErrCoolingDown {
+				t.Fatalf("round %d: expected ErrCoolingDown, got: %v", number+1, err)
+			}
+		}
+
+		// A cowboy without a speed shoots as much as the master lets it.
+		for i := 0; i < 2; i++ {
+			if err := fire(state, "legacy", "slow"); err != nil {
+				t.Fatalf("round %d: unexpected shot err: %v", number+1, err)
+			}
+		}
+
+		virtual.Advance(time.Second)
+	}
+}
+
+func TestGameSpeedWeaponFireRate(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, armed(&domain.Weapon{Damage: 1, FireRate: 1})...)
+	virtual := virtualClock(state)
+	state.players["test_1"].Speed = 4
+
+	emitRound(t, state)
+	virtual.Advance(time.Second)
+
+	// The weapon fires once per second whatever the speed of the cowboy.
+	if round := emitRound(t, state); round.Players["test_1"].Shots != 1 {
+		t.Fatalf("expected the weapon to limit the shots, got %d", round.Players["test_1"].Shots)
+	}
+}
+
+func TestGameSpeedLockstep(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{Lockstep: true},
+		&domain.Player{ID: "test_1", Name: "Test1", Health: 10, Damage: 1, Speed: 2},
+		&domain.Player{ID: "test_2", Name: "Test2", Health: 10, Damage: 1, Speed: 1},
+	)
+
+	virtual := virtualClock(state)
+
+	emitRound(t, state)
+	virtual.Advance(time.Second)
+	round := emitRound(t, state)
+
+	for i := 0; i < 3; i++ {
+		shoot(t, state, "test_1", "test_2", round.Number)
+		shoot(t, state, "test_2", "test_1", round.Number)
+	}
+
+	virtual.Advance(time.Second)
+	round = emitRound(t, state)
+
+	// Only the shots granted by the round are kept.
+	if health := round.Players["test_2"].Health; health != 8 {
+		t.Fatalf("expected test_2 to take 2 shots, got %d health", health)
+	}
+
+	if health := round.Players["test_1"].Health; health != 9 {
+		t.Fatalf("expected test_1 to take 1 shot, got %d health", health)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	cfg := &SimulationConfig{
+		Players: []*domain.Player{
+			{ID: "gunslinger", Name: "Gunslinger", Health: 10, Damage: 1, Speed: 3},
+			{ID: "heavy", Name: "Heavy", Health: 10, Damage: 2, Speed: 1},
+		},
+		Targeter: strategy.Weakest{},
+	}
+
+	simulation, err := Simulate(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("unexpected simulation err: %v", err)
+	}
+
+	// Three shots of 1 per second beat one shot of 2: the tenth shot of the gunslinger is due after 3 seconds,
+	// when the heavy hitter only hit it three times.
+	result := simulation.Result
+	if result.Winner == nil || result.Winner.ID != "gunslinger" || result.Winner.Health != 4 {
+		t.Fatalf("expected the gunslinger to win with 4 health, got %+v", result)
+	}
+
+	if simulation.Elapsed.Round(time.Millisecond) != 3*time.Second {
+		t.Fatalf("expected the game to last 3 virtual seconds, got %s", simulation.Elapsed)
+	}
+
+	again, err := Simulate(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("unexpected simulation err: %v", err)
+	}
+
+	if again.Rounds != simulation.Rounds || again.Result.Winner.Health != result.Winner.Health {
+		t.Fatalf("expected the simulation to be deterministic, got %+v and %+v", simulation, again)
+	}
+}
+
+func TestSimulateMaxRounds(t *testing.T) {
+	simulation, err := Simulate(context.Background(), &SimulationConfig{
+		Players: []*domain.Player{
+			{ID: "test_1", Name: "Test1", Health: 100, Damage: 1},
+			{ID: "test_2", Name: "Test2", Health: 100, Damage: 1},
+		},
+		Targeter:  strategy.Weakest{},
+		MaxRounds: 5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected simulation err: %v", err)
+	}
+
+	if simulation.Result.Reason == "" || simulation.Rounds != 5 {
+		t.Fatalf("expected the game to be aborted after 5 rounds, got %+v", simulation)
+	}
+}
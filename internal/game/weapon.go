@@ -0,0 +1,136 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// gun is the timing state of the weapon of a cowboy, hidden from the others.
+type gun struct {
+	reloadedAt time.Time
+	lastShot   time.Time
+	// nextShot is the next instant of the cooldown timeline of a cowboy with a speed.
+	nextShot time.Time
+}
+
+// arm equips a registering cowboy. A cowboy without a weapon gets one firing its damage,
+// which never needs a reload.
+func (gs *Game) arm(player *domain.Player) error {
+	if player.Weapon == nil {
+		player.Weapon = &domain.Weapon{Damage: player.Damage}
+	}
+
+	if err := player.Weapon.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPlayerRegistration, err)
+	}
+
+	player.Damage = player.Weapon.Damage
+	player.Ammo = player.Weapon.Magazine
+	player.Reloading = false
+	gs.guns[player.ID] = &gun{}
+
+	return nil
+}
+
+// fire consumes a shot of the player. It fails while the player reloads, has no shot left in the round
+// or fires faster than its weapon or the shot cooldown. A stunned player loses the shot without spending ammo.
+// The last shot of the magazine starts the reload.
+func (gs *Game) fire(player *domain.Player) error {
+	gs.reloaded(player)
+
+	if player.Reloading {
+		return ErrReloading
+	}
+
+	gun, now := gs.guns[player.ID], gs.clock.Now()
+	switch {
+	case player.Speed > 0:
+		// The timeline already accounts for the fire rate of the weapon and the shot cooldown.
+		if player.Shots < 1 {
+			return ErrCoolingDown
+		}
+
+		player.Shots--
+	case !gun.lastShot.IsZero() && now.Sub(gun.lastShot) < gs.cooldown(player):
+		return ErrFiringTooFast
+	}
+
+	gun.lastShot = now
+
+	if consume(player, domain.EffectStun) {
+		log.Printf("%s is stunned and skips a shot", player.Name)
+		return ErrStunned
+	}
+
+	if player.Weapon.Magazine > 0 {
+		player.Ammo--
+		if player.Ammo < 1 {
+			gs.reload(player)
+		}
+	}
+
+	return nil
+}
+
+// cooldown is the shortest delay between two shots of a cowboy, the longest of its own cooldown and the shot
+// cooldown of the game.
+func (gs *Game) cooldown(player *domain.Player) time.Duration {
+	if cooldown := player.Cooldown(); cooldown > gs.shotCooldown {
+		return cooldown
+	}
+
+	return gs.shotCooldown
+}
+
+// reload starts reloading the weapon of the player.
+func (gs *Game) reload(player *domain.Player) {
+	player.Reloading = true
+	gs.guns[player.ID].reloadedAt = gs.clock.Now().Add(player.Weapon.ReloadTime)
+
+	log.Printf("%s is reloading", player.Name)
+}
+
+// reloaded completes the reload of the player once its reload time elapsed.
+func (gs *Game) reloaded(player *domain.Player) {
+	if !player.Reloading || gs.clock.Now().Before(gs.guns[player.ID].reloadedAt) {
+		return
+	}
+
+	player.Reloading = false
+	player.Ammo = player.Weapon.Magazine
+}
+
+// handlePlayerReload starts the reload a player asked for.
+func (gs *Game) handlePlayerReload(event *Event) error {
+	if !gs.started() {
+		return ErrGameNotStarted
+	}
+
+	var reload domain.Reload
+	if err := json.Unmarshal(event.Data, &reload); err != nil {
+		return fmt.Errorf("failed to unmarshal player reload payload: %w", err)
+	}
+
+	player, ok := gs.players[reload.ID]
+	if !ok {
+		return ErrInvalidPayload
+	}
+
+	gs.reloaded(player)
+
+	switch {
+	case player.Reloading:
+		return ErrReloading
+	case player.Weapon.Magazine == 0 || player.Ammo == player.Weapon.Magazine:
+		// Nothing to reload.
+		return nil
+	}
+
+	gs.reload(player)
+
+	return nil
+}
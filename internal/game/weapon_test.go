@@ -0,0 +1,154 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// armed are a cowboy fighting with the weapon and a plain one.
+func armed(weapon *domain.Weapon) []*domain.Player {
+	return []*domain.Player{
+		{ID: "test_1", Name: "Test1", Health: 10, Weapon: weapon},
+		{ID: "test_2", Name: "Test2", Health: 10, Damage: 1},
+	}
+}
+
+func fire(state *Game, from, to string) error {
+	shot, _ := NewEvent(EventShot, &domain.Action{Src: from, Dest: to})
+	return state.HandleEvent(shot)
+}
+
+func TestGameWeaponMagazine(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, armed(&domain.Weapon{Damage: 2, Magazine: 2, ReloadTime: 2 * time.Second})...)
+	virtual := virtualClock(state)
+
+	round := emitRound(t, state)
+	if shooter := round.Players["test_1"]; shooter.Damage != 2 || shooter.Ammo != 2 || shooter.Reloading {
+		t.Fatalf("expected a loaded weapon, got %+v", shooter)
+	}
+
+	// Cowboys registering with a damage only never reload.
+	if other := round.Players["test_2"]; other.Weapon == nil || other.Weapon.Damage != 1 || other.Weapon.Magazine != 0 {
+		t.Fatalf("expected a default weapon, got %+v", other.Weapon)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := fire(state, "test_1", "test_2"); err != nil {
+			t.Fatalf("unexpected shot err: %v", err)
+		}
+	}
+
+	if err := fire(state, "test_1", "test_2"); err != ErrReloading {
+		t.Fatalf("expected ErrReloading with an empty magazine, got: %v", err)
+	}
+
+	round = emitRound(t, state)
+	if shooter := round.Players["test_1"]; !shooter.Reloading || shooter.Ammo != 0 {
+		t.Fatalf("expected test_1 to be reloading, got %+v", shooter)
+	}
+
+	if target := round.Players["test_2"]; target.Health != 6 {
+		t.Fatalf("expected test_2 to have taken 2 shots, got %d health", target.Health)
+	}
+
+	virtual.Advance(2 * time.Second)
+
+	round = emitRound(t, state)
+	if shooter := round.Players["test_1"]; shooter.Reloading || shooter.Ammo != 2 {
+		t.Fatalf("expected test_1 to have reloaded, got %+v", shooter)
+	}
+}
+
+func TestGameWeaponReload(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, armed(&domain.Weapon{Damage: 1, Magazine: 3, ReloadTime: time.Second})...)
+	virtual := virtualClock(state)
+	emitRound(t, state)
+
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	reload, _ := NewEvent(EventReload, &domain.Reload{ID: "test_1"})
+	if err := state.HandleEvent(reload); err != nil {
+		t.Fatalf("unexpected reload err: %v", err)
+	}
+
+	if err := state.HandleEvent(reload); err != ErrReloading {
+		t.Fatalf("expected ErrReloading reloading twice, got: %v", err)
+	}
+
+	if err := fire(state, "test_1", "test_2"); err != ErrReloading {
+		t.Fatalf("expected ErrReloading, got: %v", err)
+	}
+
+	virtual.Advance(time.Second)
+
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err after the reload: %v", err)
+	}
+
+	if ammo := state.players["test_1"].Ammo; ammo != 2 {
+		t.Fatalf("expected 2 shots left, got %d", ammo)
+	}
+}
+
+func TestGameWeaponFireRate(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{}, armed(&domain.Weapon{Damage: 1, FireRate: 2})...)
+	virtual := virtualClock(state)
+	emitRound(t, state)
+
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	virtual.Advance(400 * time.Millisecond)
+
+	if err := fire(state, "test_1", "test_2"); err != ErrFiringTooFast {
+		t.Fatalf("expected ErrFiringTooFast, got: %v", err)
+	}
+
+	virtual.Advance(100 * time.Millisecond)
+
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err after the cooldown: %v", err)
+	}
+}
+
+func TestGameWeaponInvalid(t *testing.T) {
+	state := NewGame(&domain.MasterConfig{Players: 2})
+
+	registration, _ := NewEvent(Registration, &domain.Player{
+		ID:     "test_1",
+		Name:   "Test1",
+		Health: 10,
+		Weapon: &domain.Weapon{Damage: 1, Magazine: -1},
+	})
+	if err := state.HandleEvent(registration); err == nil {
+		t.Fatal("expected an invalid weapon to be rejected")
+	}
+}
+
+func TestGameShotCooldown(t *testing.T) {
+	state := newGame(t, &domain.MasterConfig{ShotCooldown: time.Second}, armed(&domain.Weapon{Damage: 1, FireRate: 4})...)
+	virtual := virtualClock(state)
+	emitRound(t, state)
+
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err: %v", err)
+	}
+
+	// The shot cooldown of the game is longer than the one of the weapon.
+	virtual.Advance(500 * time.Millisecond)
+
+	if err := fire(state, "test_1", "test_2"); err != ErrFiringTooFast {
+		t.Fatalf("expected ErrFiringTooFast, got: %v", err)
+	}
+
+	virtual.Advance(500 * time.Millisecond)
+
+	if err := fire(state, "test_1", "test_2"); err != nil {
+		t.Fatalf("unexpected shot err after the cooldown: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package generate
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"text/template"
+
+	"github.com/reactivejson/cowboys/internal/roster"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+var templates = template.Must(template.ParseFS(templatesFS, "templates/*.tmpl"))
+
+// dnsLabel is the shape of a name usable in compose service names and Kubernetes resource names.
+var dnsLabel = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Options locate the generated files and the images they run.
+type Options struct {
+	// Source is the roster file, mentioned in the generated files.
+	Source string
+	// Out is the directory the paths of the files are relative to.
+	Out string
+	// Registry prefixes the images of the Kubernetes manifests, e.g. "127.0.0.1:5000/".
+	Registry string
+	Tag      string
+}
+
+// File is a generated file.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// outputs maps every template to the file it generates.
+var outputs = []struct {
+	template, path string
+}{
+	{"docker-compose.yml.tmpl", "docker-compose.yml"},
+	{"master-values.yaml.tmpl", filepath.Join("helm", "master", "values.roster.yaml")},
+	{"player-values.yaml.tmpl", filepath.Join("helm", "player", "values.roster.yaml")},
+	{"kubernetes.yaml.tmpl", filepath.Join("k8s", "cowboys.yaml")},
+}
+
+// Generate renders the deployment files of a roster: the docker compose file, the helm values of both
+// charts and plain Kubernetes manifests.
+func Generate(r *roster.Roster, opts *Options) ([]*File, error) {
+	if err := Validate(r); err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		*Options
+		Players []*roster.Entry
+	}{
+		Options: opts,
+		Players: r.Players,
+	}
+
+	files := make([]*File, 0, len(outputs))
+	for _, output := range outputs {
+		var content bytes.Buffer
+		if err := templates.ExecuteTemplate(&content, output.template, &data); err != nil {
+			return nil, fmt.Errorf("render %s: %w", output.path, err)
+		}
+
+		files = append(files, &File{
+			Path:    filepath.Join(opts.Out, output.path),
+			Content: content.Bytes(),
+		})
+	}
+
+	return files, nil
+}
+
+// Validate checks the roster makes a game and that the names of the cowboys can name containers and resources.
+func Validate(r *roster.Roster) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	for _, entry := range r.Players {
+		if len(entry.Name) > 56 || !dnsLabel.MatchString(entry.Name) {
+			return fmt.Errorf("cowboy %q: names are made of at most 56 lower case letters, digits and dashes", entry.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package generate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/roster"
+)
+
+// TestGeneratedFilesUpToDate fails when the committed files were not regenerated after a change of the roster
+// or of the templates. Run `make generate` to fix it.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+
+	players, err := roster.Load(filepath.Join(root, "players.json"))
+	if err != nil {
+		t.Fatalf("load roster: %v", err)
+	}
+
+	files, err := Generate(players, &Options{Source: "players.json", Out: root, Tag: "latest"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	for _, file := range files {
+		committed, err := os.ReadFile(file.Path)
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Path, err)
+		}
+
+		if !bytes.Equal(committed, file.Content) {
+			t.Errorf("%s is out of date, run make generate", file.Path)
+		}
+	}
+}
+
+func TestGenerateContent(t *testing.T) {
+	files, err := Generate(&roster.Roster{Players: []*roster.Entry{
+		{Name: "bill", Health: 7, Damage: 2, Speed: 2.5, Allies: []string{"jesse"}},
+		{Name: "jesse", Health: 4, Damage: 3, Weapon: "rifle", Magazine: 5, ReloadTime: "1500ms", Effects: "bleeding:1:3:0.5"},
+	}}, &Options{Source: "duel.json", Registry: "registry:5000/", Tag: "1.2.3"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	expected := map[string][]string{
+		"docker-compose.yml":             {"COMPETITORS: 2", "player-bill:", "HEALTH: 4", "SPEED: 2.5", `WEAPON_EFFECTS: "bleeding:1:3:0.5"`, "ALLIES: jesse", "from duel.json"},
+		"helm/master/values.roster.yaml": {"competitors: 2"},
+		"helm/player/values.roster.yaml": {"- name: jesse\n    health: 4\n    damage: 3"},
+		"k8s/cowboys.yaml":               {"name: player-jesse", "image: registry:5000/player:1.2.3", `value: "2"`, "name: SPEED\n              value: \"2.5\""},
+	}
+
+	for _, file := range files {
+		for _, fragment := range expected[filepath.ToSlash(file.Path)] {
+			if !strings.Contains(string(file.Content), fragment) {
+				t.Errorf("expected %q in %s:\n%s", fragment, file.Path, file.Content)
+			}
+		}
+	}
+}
+
+func TestGenerateInvalidNames(t *testing.T) {
+	for _, name := range []string{"Bill", "bill the kid", "-bill", strings.Repeat("b", 57)} {
+		_, err := Generate(&roster.Roster{Players: []*roster.Entry{
+			{Name: name, Health: 1, Damage: 1},
+			{Name: "jesse", Health: 1, Damage: 1},
+		}}, &Options{})
+		if err == nil {
+			t.Errorf("%q: expected an error", name)
+		}
+	}
+}
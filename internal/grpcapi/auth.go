@@ -0,0 +1,43 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	cowboysv1 "github.com/reactivejson/cowboys/api/cowboys/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// adminMethods are the methods reserved to the operators, like the admin API over HTTP.
+var adminMethods = map[string]bool{
+	cowboysv1.Arena_Abort_FullMethodName: true,
+}
+
+// Authorize guards the admin methods with the admin token, sent as a bearer token in the authorization metadata.
+// Without an admin token, the admin methods are refused.
+func Authorize(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !adminMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		if token == "" {
+			return nil, status.Error(codes.PermissionDenied, "admin methods are disabled without an admin token")
+		}
+
+		var bearer string
+		if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+			bearer = strings.TrimPrefix(values[0], "Bearer ")
+		}
+
+		if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,171 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	cowboysv1 "github.com/reactivejson/cowboys/api/cowboys/v1"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"github.com/reactivejson/cowboys/internal/transport"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// Client lets a player play over gRPC instead of the HTTP registration and Redis: it registers the cowboy
+// and acts as the transport, whatever the topic, receiving the broadcast events and submitting its own.
+type Client struct {
+	conn   *grpc.ClientConn
+	arena  cowboysv1.ArenaClient
+	logger *log.Logger
+}
+
+// Dial connects to the gRPC API of the master. The logger reports the failures of the event streams.
+func Dial(addr string, logger *log.Logger) (*Client, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dial master gRPC API: %w", err)
+	}
+
+	return &Client{
+		conn:   conn,
+		arena:  cowboysv1.NewArenaClient(conn),
+		logger: logger,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Register joins the game with the configured cowboy.
+func (c *Client) Register(ctx context.Context, cfg *domain.PlayerConfig) (*domain.Player, error) {
+	resp, err := c.arena.Join(ctx, &cowboysv1.JoinRequest{
+		Name:   cfg.Name,
+		Health: int32(cfg.Health),
+		Damage: int32(cfg.Damage),
+		Weapon: toWeapon(cfg.Arm()),
+		Speed:  cfg.Speed,
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil, fmt.Errorf("join over gRPC: %w: %s", domain.ErrNotAdmitted, status.Convert(err).Message())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("join over gRPC: %w", err)
+	}
+
+	return &domain.Player{
+		ID:        resp.Cowboy.Id,
+		Name:      resp.Cowboy.Name,
+		Health:    int(resp.Cowboy.Health),
+		Damage:    int(resp.Cowboy.Damage),
+		Weapon:    fromWeapon(resp.Cowboy.Weapon),
+		Ammo:      int(resp.Cowboy.Ammo),
+		Reloading: resp.Cowboy.Reloading,
+		Speed:     resp.Cowboy.Speed,
+	}, nil
+}
+
+// Publish submits a player event to the master.
+func (c *Client) Publish(ctx context.Context, _ string, payload []byte) error {
+	var event game.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("unmarshal event: %w", err)
+	}
+
+	message, err := toProto(&event)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.arena.Submit(ctx, message); err != nil {
+		return fmt.Errorf("submit %s event: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+// Subscribe streams the events the master broadcasts to the players.
+func (c *Client) Subscribe(ctx context.Context, topic string) transport.Subscription {
+	ctx, cancel := context.WithCancel(ctx)
+
+	subscription := &subscription{
+		messages: make(chan *transport.Message),
+		cancel:   cancel,
+		logger:   c.logger,
+	}
+
+	go subscription.receive(ctx, c.arena, topic)
+
+	return subscription
+}
+
+type subscription struct {
+	messages chan *transport.Message
+	cancel   context.CancelFunc
+	once     sync.Once
+	logger   *log.Logger
+}
+
+// receive relays the streamed events until the stream fails or the subscription is closed. The failures are
+// logged, as the subscriber only sees the channel close.
+func (s *subscription) receive(ctx context.Context, arena cowboysv1.ArenaClient, topic string) {
+	defer close(s.messages)
+
+	stream, err := arena.StreamEvents(ctx, &cowboysv1.StreamEventsRequest{})
+	if err != nil {
+		s.fail(ctx, "open gRPC event stream", err)
+		return
+	}
+
+	for {
+		message, err := stream.Recv()
+		if err != nil {
+			s.fail(ctx, "receive gRPC event", err)
+			return
+		}
+
+		event, err := fromProto(message)
+		if err != nil {
+			s.logger.Printf("convert %s event: %v", message.Type, err)
+			continue
+		}
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			s.logger.Printf("marshal %s event: %v", message.Type, err)
+			continue
+		}
+
+		select {
+		case s.messages <- &transport.Message{Topic: topic, Payload: payload}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fail logs the error ending the stream, unless the subscription was closed.
+func (s *subscription) fail(ctx context.Context, what string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.logger.Printf("%s: %v", what, err)
+}
+
+func (s *subscription) Channel() <-chan *transport.Message {
+	return s.messages
+}
+
+func (s *subscription) Close() error {
+	s.once.Do(s.cancel)
+
+	return nil
+}
@@ -0,0 +1,460 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cowboysv1 "github.com/reactivejson/cowboys/api/cowboys/v1"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toProto converts a game event to its envelope. The JSON data is always kept, and the payload
+// of the event types known to the contract is set as well.
+func toProto(event *game.Event) (*cowboysv1.GameEvent, error) {
+	message := &cowboysv1.GameEvent{
+		Type: string(event.Type),
+		Json: event.Data,
+		Game: event.Game,
+	}
+
+	var err error
+
+	switch event.Type {
+	case game.Registration:
+		var player domain.Player
+		if err = json.Unmarshal(event.Data, &player); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Registration{Registration: toCowboy(&player)}
+		}
+	case game.EventCountdown:
+		var countdown domain.Countdown
+		if err = json.Unmarshal(event.Data, &countdown); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Countdown{Countdown: &cowboysv1.Countdown{
+				StartAt:    timestamppb.New(countdown.StartAt),
+				ServerTime: timestamppb.New(countdown.ServerTime),
+			}}
+		}
+	case game.EventRound:
+		var round domain.Round
+		if err = json.Unmarshal(event.Data, &round); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Round{Round: toRound(&round)}
+		}
+	case game.EventKill:
+		var kill domain.Kill
+		if err = json.Unmarshal(event.Data, &kill); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Kill{Kill: &cowboysv1.Kill{Shooter: kill.Shooter, Target: kill.Target}}
+		}
+	case game.EventMessage:
+		var msg domain.Message
+		if err = json.Unmarshal(event.Data, &msg); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Message{Message: &cowboysv1.Message{From: msg.From, To: msg.To, Text: msg.Text}}
+		}
+	case game.EventAlliance:
+		var alliance domain.Alliance
+		if err = json.Unmarshal(event.Data, &alliance); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Alliance{Alliance: &cowboysv1.Alliance{
+				From: alliance.From,
+				To:   alliance.To,
+				Kind: string(alliance.Kind),
+			}}
+		}
+	case game.EventBetrayal:
+		var betrayal domain.Betrayal
+		if err = json.Unmarshal(event.Data, &betrayal); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Betrayal{Betrayal: &cowboysv1.Betrayal{Traitor: betrayal.Traitor, Victim: betrayal.Victim}}
+		}
+	case game.EventGameOver:
+		var result domain.Result
+		if err = json.Unmarshal(event.Data, &result); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Result{Result: toResult(&result)}
+		}
+	case game.EventReady:
+		var ready domain.Ready
+		if err = json.Unmarshal(event.Data, &ready); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Ready{Ready: &cowboysv1.Ready{Id: ready.ID}}
+		}
+	case game.EventShot, game.EventAction:
+		var action domain.Action
+		if err = json.Unmarshal(event.Data, &action); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Shot{Shot: &cowboysv1.Shot{
+				From:  action.Src,
+				To:    action.Dest,
+				Round: int32(action.Round),
+				Move:  toPosition(action.Move),
+				Kind:  string(action.Kind),
+			}}
+		}
+	case game.EventReload:
+		var reload domain.Reload
+		if err = json.Unmarshal(event.Data, &reload); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Reload{Reload: &cowboysv1.Reload{Id: reload.ID}}
+		}
+	case game.EventResync:
+		var resync domain.Resync
+		if err = json.Unmarshal(event.Data, &resync); err == nil {
+			message.Payload = &cowboysv1.GameEvent_Resync{Resync: &cowboysv1.Resync{Id: resync.ID, Last: int32(resync.Last)}}
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal %s event data: %w", event.Type, err)
+	}
+
+	return message, nil
+}
+
+// fromProto converts an envelope to a game event. The JSON data wins over the payload, which
+// clients of other languages may send instead.
+func fromProto(message *cowboysv1.GameEvent) (*game.Event, error) {
+	event, err := fromPayload(message)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Game = message.Game
+
+	return event, nil
+}
+
+// fromPayload converts the data of an envelope, its JSON data or else its typed payload.
+func fromPayload(message *cowboysv1.GameEvent) (*game.Event, error) {
+	if len(message.Json) > 0 {
+		return &game.Event{
+			Type: game.EventType(message.Type),
+			Data: message.Json,
+		}, nil
+	}
+
+	eventType := game.EventType(message.Type)
+
+	switch payload := message.Payload.(type) {
+	case *cowboysv1.GameEvent_Registration:
+		return game.NewEvent(eventType, fromCowboy(payload.Registration))
+	case *cowboysv1.GameEvent_Countdown:
+		return game.NewEvent(eventType, &domain.Countdown{
+			StartAt:    payload.Countdown.StartAt.AsTime(),
+			ServerTime: payload.Countdown.ServerTime.AsTime(),
+		})
+	case *cowboysv1.GameEvent_Round:
+		return game.NewEvent(eventType, fromRound(payload.Round))
+	case *cowboysv1.GameEvent_Kill:
+		return game.NewEvent(eventType, &domain.Kill{Shooter: payload.Kill.Shooter, Target: payload.Kill.Target})
+	case *cowboysv1.GameEvent_Result:
+		return game.NewEvent(eventType, fromResult(payload.Result))
+	case *cowboysv1.GameEvent_Betrayal:
+		return game.NewEvent(eventType, &domain.Betrayal{Traitor: payload.Betrayal.Traitor, Victim: payload.Betrayal.Victim})
+	case *cowboysv1.GameEvent_Resync:
+		return game.NewEvent(eventType, &domain.Resync{ID: payload.Resync.Id, Last: int(payload.Resync.Last)})
+	case *cowboysv1.GameEvent_Ready:
+		return game.NewEvent(eventType, &domain.Ready{ID: payload.Ready.Id})
+	case *cowboysv1.GameEvent_Shot:
+		return game.NewEvent(eventType, &domain.Action{
+			Src:   payload.Shot.From,
+			Dest:  payload.Shot.To,
+			Round: int(payload.Shot.Round),
+			Move:  fromPosition(payload.Shot.Move),
+			Kind:  domain.ActionKind(payload.Shot.Kind),
+		})
+	case *cowboysv1.GameEvent_Reload:
+		return game.NewEvent(eventType, &domain.Reload{ID: payload.Reload.Id})
+	case *cowboysv1.GameEvent_Message:
+		return game.NewEvent(eventType, &domain.Message{
+			From: payload.Message.From,
+			To:   payload.Message.To,
+			Text: payload.Message.Text,
+		})
+	case *cowboysv1.GameEvent_Alliance:
+		return game.NewEvent(eventType, &domain.Alliance{
+			From: payload.Alliance.From,
+			To:   payload.Alliance.To,
+			Kind: domain.AllianceKind(payload.Alliance.Kind),
+		})
+	case nil:
+		return &game.Event{Type: eventType}, nil
+	default:
+		return nil, fmt.Errorf("unsupported %T payload", payload)
+	}
+}
+
+func toCowboy(player *domain.Player) *cowboysv1.Cowboy {
+	if player == nil {
+		return nil
+	}
+
+	return &cowboysv1.Cowboy{
+		Id:        player.ID,
+		Name:      player.Name,
+		Health:    int32(player.Health),
+		Damage:    int32(player.Damage),
+		Weapon:    toWeapon(player.Weapon),
+		Ammo:      int32(player.Ammo),
+		Reloading: player.Reloading,
+		Speed:     player.Speed,
+		Shots:     int32(player.Shots),
+		Position:  toPosition(player.Position),
+		Effects:   toEffects(player.Effects),
+		MaxHealth: int32(player.MaxHealth),
+		Heals:     int32(player.Heals),
+		Allies:    player.Allies,
+	}
+}
+
+func fromCowboy(cowboy *cowboysv1.Cowboy) *domain.Player {
+	if cowboy == nil {
+		return nil
+	}
+
+	player := &domain.Player{
+		ID:        cowboy.Id,
+		Name:      cowboy.Name,
+		Health:    int(cowboy.Health),
+		Damage:    int(cowboy.Damage),
+		Weapon:    fromWeapon(cowboy.Weapon),
+		Ammo:      int(cowboy.Ammo),
+		Reloading: cowboy.Reloading,
+		Speed:     cowboy.Speed,
+		Shots:     int(cowboy.Shots),
+		Position:  fromPosition(cowboy.Position),
+		MaxHealth: int(cowboy.MaxHealth),
+		Heals:     int(cowboy.Heals),
+		Allies:    cowboy.Allies,
+	}
+
+	for _, message := range cowboy.Effects {
+		effect := fromEffect(message)
+		player.Effects = append(player.Effects, &effect)
+	}
+
+	return player
+}
+
+func toEffects(effects []*domain.Effect) []*cowboysv1.Effect {
+	var messages []*cowboysv1.Effect
+	for _, effect := range effects {
+		messages = append(messages, toEffect(effect))
+	}
+
+	return messages
+}
+
+func toEffect(effect *domain.Effect) *cowboysv1.Effect {
+	return &cowboysv1.Effect{
+		Kind:      string(effect.Kind),
+		Magnitude: int32(effect.Magnitude),
+		Rounds:    int32(effect.Rounds),
+		Stacking:  string(effect.Stacking),
+		Stacks:    int32(effect.Stacks),
+		Source:    effect.Source,
+	}
+}
+
+func fromEffect(effect *cowboysv1.Effect) domain.Effect {
+	return domain.Effect{
+		Kind:      domain.EffectKind(effect.GetKind()),
+		Magnitude: int(effect.GetMagnitude()),
+		Rounds:    int(effect.GetRounds()),
+		Stacking:  domain.Stacking(effect.GetStacking()),
+		Stacks:    int(effect.GetStacks()),
+		Source:    effect.GetSource(),
+	}
+}
+
+func toPosition(position *domain.Position) *cowboysv1.Position {
+	if position == nil {
+		return nil
+	}
+
+	return &cowboysv1.Position{X: int32(position.X), Y: int32(position.Y)}
+}
+
+func fromPosition(position *cowboysv1.Position) *domain.Position {
+	if position == nil {
+		return nil
+	}
+
+	return &domain.Position{X: int(position.X), Y: int(position.Y)}
+}
+
+func toGrid(grid *domain.Grid) *cowboysv1.Grid {
+	if grid == nil {
+		return nil
+	}
+
+	message := &cowboysv1.Grid{Width: int32(grid.Width), Height: int32(grid.Height)}
+	for i := range grid.Cover {
+		message.Cover = append(message.Cover, toPosition(&grid.Cover[i]))
+	}
+
+	return message
+}
+
+func fromGrid(grid *cowboysv1.Grid) *domain.Grid {
+	if grid == nil {
+		return nil
+	}
+
+	result := &domain.Grid{Width: int(grid.Width), Height: int(grid.Height)}
+	for _, tile := range grid.Cover {
+		result.Cover = append(result.Cover, *fromPosition(tile))
+	}
+
+	return result
+}
+
+func toWeapon(weapon *domain.Weapon) *cowboysv1.Weapon {
+	if weapon == nil {
+		return nil
+	}
+
+	return &cowboysv1.Weapon{
+		Name:       weapon.Name,
+		Damage:     int32(weapon.Damage),
+		Magazine:   int32(weapon.Magazine),
+		ReloadTime: durationpb.New(weapon.ReloadTime),
+		FireRate:   weapon.FireRate,
+		Effects:    toOnHits(weapon.Effects),
+	}
+}
+
+func toOnHits(effects domain.OnHits) []*cowboysv1.OnHit {
+	var messages []*cowboysv1.OnHit
+	for _, hit := range effects {
+		messages = append(messages, &cowboysv1.OnHit{
+			Effect: toEffect(&hit.Effect),
+			Chance: hit.Chance,
+			Self:   hit.Self,
+		})
+	}
+
+	return messages
+}
+
+func fromOnHits(messages []*cowboysv1.OnHit) domain.OnHits {
+	var effects domain.OnHits
+	for _, message := range messages {
+		effects = append(effects, &domain.OnHit{
+			Effect: fromEffect(message.Effect),
+			Chance: message.Chance,
+			Self:   message.Self,
+		})
+	}
+
+	return effects
+}
+
+func fromWeapon(weapon *cowboysv1.Weapon) *domain.Weapon {
+	if weapon == nil {
+		return nil
+	}
+
+	return &domain.Weapon{
+		Name:       weapon.Name,
+		Damage:     int(weapon.Damage),
+		Magazine:   int(weapon.Magazine),
+		ReloadTime: weapon.ReloadTime.AsDuration(),
+		FireRate:   weapon.FireRate,
+		Effects:    fromOnHits(weapon.Effects),
+	}
+}
+
+func toCowboys(players []*domain.Player) []*cowboysv1.Cowboy {
+	cowboys := make([]*cowboysv1.Cowboy, 0, len(players))
+	for _, player := range players {
+		cowboys = append(cowboys, toCowboy(player))
+	}
+
+	return cowboys
+}
+
+func toRound(round *domain.Round) *cowboysv1.Round {
+	players := make([]*domain.Player, 0, len(round.Players))
+	for _, player := range round.Players {
+		players = append(players, player)
+	}
+
+	sortPlayers(players)
+
+	return &cowboysv1.Round{
+		Number:           int32(round.Number),
+		Cowboys:          toCowboys(players),
+		DamageMultiplier: int32(round.DamageMultiplier),
+		Grid:             toGrid(round.Grid),
+		Delta:            round.Delta,
+		Removed:          round.Removed,
+	}
+}
+
+func fromRound(message *cowboysv1.Round) *domain.Round {
+	round := &domain.Round{
+		Players:          make(map[string]*domain.Player, len(message.Cowboys)),
+		Number:           int(message.Number),
+		DamageMultiplier: int(message.DamageMultiplier),
+		Grid:             fromGrid(message.Grid),
+		Delta:            message.Delta,
+		Removed:          message.Removed,
+	}
+
+	for _, cowboy := range message.Cowboys {
+		round.Players[cowboy.Id] = fromCowboy(cowboy)
+	}
+
+	return round
+}
+
+func toResult(result *domain.Result) *cowboysv1.Result {
+	return &cowboysv1.Result{
+		Winner:    toCowboy(result.Winner),
+		Draw:      result.Draw,
+		Reason:    result.Reason,
+		Standings: toCowboys(result.Standings),
+	}
+}
+
+func fromResult(message *cowboysv1.Result) *domain.Result {
+	result := &domain.Result{
+		Winner:    fromCowboy(message.Winner),
+		Draw:      message.Draw,
+		Reason:    message.Reason,
+		Standings: make([]*domain.Player, 0, len(message.Standings)),
+	}
+
+	for _, cowboy := range message.Standings {
+		result.Standings = append(result.Standings, fromCowboy(cowboy))
+	}
+
+	return result
+}
+
+func toState(state *domain.State) *cowboysv1.GameState {
+	message := &cowboysv1.GameState{
+		Phase:      toPhase(state.Phase),
+		Round:      int32(state.Round),
+		Cowboys:    toCowboys(state.Players),
+		Eliminated: toCowboys(state.Eliminated),
+	}
+
+	if !state.StartAt.IsZero() {
+		message.StartAt = timestamppb.New(state.StartAt)
+	}
+
+	return message
+}
+
+func toPhase(phase domain.Phase) cowboysv1.Phase {
+	switch phase {
+	case domain.PhaseWaiting:
+		return cowboysv1.Phase_PHASE_WAITING
+	case domain.PhaseReadyCheck:
+		return cowboysv1.Phase_PHASE_READY_CHECK
+	case domain.PhaseCountdown:
+		return cowboysv1.Phase_PHASE_COUNTDOWN
+	case domain.PhaseFighting:
+		return cowboysv1.Phase_PHASE_FIGHTING
+	case domain.PhaseFinished:
+		return cowboysv1.Phase_PHASE_FINISHED
+	default:
+		return cowboysv1.Phase_PHASE_UNSPECIFIED
+	}
+}
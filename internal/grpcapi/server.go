@@ -0,0 +1,127 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+
+	cowboysv1 "github.com/reactivejson/cowboys/api/cowboys/v1"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Backend is the master side of the API.
+type Backend interface {
+	Register(name string, health, damage int, speed float64, weapon *domain.Weapon) (*domain.Player, error)
+	State() *domain.State
+	// Subscribe streams the events broadcast to the players, and the recorded ones with journal.
+	Subscribe(journal bool) (<-chan *game.Event, func())
+	Submit(event *game.Event) error
+	Abort(reason string) error
+}
+
+// Server implements the Arena service on top of the master.
+type Server struct {
+	cowboysv1.UnimplementedArenaServer
+
+	backend Backend
+	logger  *log.Logger
+}
+
+func NewServer(backend Backend, logger *log.Logger) *Server {
+	return &Server{
+		backend: backend,
+		logger:  logger,
+	}
+}
+
+func (s *Server) Join(_ context.Context, request *cowboysv1.JoinRequest) (*cowboysv1.JoinResponse, error) {
+	player, err := s.backend.Register(request.Name, int(request.Health), int(request.Damage), request.Speed, fromWeapon(request.Weapon))
+	if err != nil {
+		return nil, s.status(err)
+	}
+
+	return &cowboysv1.JoinResponse{Cowboy: toCowboy(player)}, nil
+}
+
+func (s *Server) GetState(context.Context, *cowboysv1.GetStateRequest) (*cowboysv1.GameState, error) {
+	return toState(s.backend.State()), nil
+}
+
+func (s *Server) StreamEvents(request *cowboysv1.StreamEventsRequest, stream cowboysv1.Arena_StreamEventsServer) error {
+	events, unsubscribe := s.backend.Subscribe(request.Journal)
+	defer unsubscribe()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			message, err := toProto(event)
+			if err != nil {
+				s.logger.Printf("convert %s event: %v", event.Type, err)
+				continue
+			}
+
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (s *Server) Submit(_ context.Context, message *cowboysv1.GameEvent) (*cowboysv1.SubmitResponse, error) {
+	event, err := fromProto(message)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.backend.Submit(event); err != nil {
+		return nil, s.status(err)
+	}
+
+	return &cowboysv1.SubmitResponse{}, nil
+}
+
+func (s *Server) Abort(_ context.Context, request *cowboysv1.AbortRequest) (*cowboysv1.AbortResponse, error) {
+	reason := request.Reason
+	if reason == "" {
+		reason = "aborted over gRPC"
+	}
+
+	if err := s.backend.Abort(reason); err != nil {
+		return nil, s.status(err)
+	}
+
+	return &cowboysv1.AbortResponse{}, nil
+}
+
+// status maps the game errors to gRPC status codes.
+func (s *Server) status(err error) error {
+	switch {
+	case errors.Is(err, game.ErrGameFinished),
+		errors.Is(err, game.ErrGameAlreadyStarted),
+		errors.Is(err, game.ErrGameNotStarted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, game.ErrInvalidPayload),
+		errors.Is(err, game.ErrInvalidPlayerRegistration),
+		errors.Is(err, game.ErrForeignGame):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		s.logger.Printf("gRPC request: %v", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+func sortPlayers(players []*domain.Player) {
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].ID < players[j].ID
+	})
+}
@@ -0,0 +1,264 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	cowboysv1 "github.com/reactivejson/cowboys/api/cowboys/v1"
+	"github.com/reactivejson/cowboys/internal/domain"
+	"github.com/reactivejson/cowboys/internal/game"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeBackend struct {
+	registered *domain.Player
+	submitted  chan *game.Event
+	events     chan *game.Event
+	err        error
+}
+
+func (b *fakeBackend) Register(name string, health, damage int, speed float64, weapon *domain.Weapon) (*domain.Player, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	b.registered = &domain.Player{ID: "id-" + name, Name: name, Health: health, Damage: damage, Speed: speed, Weapon: weapon}
+
+	return b.registered, nil
+}
+
+func (b *fakeBackend) State() *domain.State {
+	return &domain.State{
+		Phase:   domain.PhaseFighting,
+		Round:   2,
+		Players: []*domain.Player{b.registered},
+	}
+}
+
+func (b *fakeBackend) Subscribe(bool) (<-chan *game.Event, func()) {
+	return b.events, func() {}
+}
+
+func (b *fakeBackend) Submit(event *game.Event) error {
+	b.submitted <- event
+	return b.err
+}
+
+func (b *fakeBackend) Abort(string) error {
+	return b.err
+}
+
+const adminToken = "s3cr3t"
+
+func serve(t *testing.T, backend Backend) *Client {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(Authorize(adminToken)))
+	cowboysv1.RegisterArenaServer(server, NewServer(backend, log.New(io.Discard, "", 0)))
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	client, err := Dial(listener.Addr().String(), log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestClientPlaysOverGRPC(t *testing.T) {
+	backend := &fakeBackend{
+		submitted: make(chan *game.Event, 1),
+		events:    make(chan *game.Event, 1),
+	}
+	client := serve(t, backend)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	player, err := client.Register(ctx, &domain.PlayerConfig{Name: "Bill", Health: 5, Damage: 2, Magazine: 6, ReloadTime: time.Second})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	if player.ID != "id-Bill" || player.Health != 5 || player.Damage != 2 {
+		t.Fatalf("unexpected registered cowboy %+v", player)
+	}
+
+	if weapon := player.Weapon; weapon == nil || weapon.Magazine != 6 || weapon.ReloadTime != time.Second {
+		t.Fatalf("unexpected registered weapon %+v", weapon)
+	}
+
+	state, err := client.arena.GetState(ctx, &cowboysv1.GetStateRequest{})
+	if err != nil {
+		t.Fatalf("get state: %v", err)
+	}
+
+	if state.Phase != cowboysv1.Phase_PHASE_FIGHTING || state.Round != 2 || len(state.Cowboys) != 1 {
+		t.Fatalf("unexpected state %v", state)
+	}
+
+	subscription := client.Subscribe(ctx, "master_events")
+	defer subscription.Close()
+
+	round, _ := game.NewEvent(game.EventRound, &domain.Round{
+		Players: map[string]*domain.Player{player.ID: player},
+		Number:  3,
+	})
+	backend.events <- round
+
+	select {
+	case msg := <-subscription.Channel():
+		var event game.Event
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			t.Fatalf("unmarshal streamed event: %v", err)
+		}
+
+		if event.Type != game.EventRound || string(event.Data) != string(round.Data) {
+			t.Fatalf("unexpected streamed event %s %s", event.Type, event.Data)
+		}
+	case <-ctx.Done():
+		t.Fatal("no event streamed")
+	}
+
+	shot, _ := game.NewEvent(game.EventShot, &domain.Action{Src: player.ID, Dest: "other", Round: 3})
+	shot.Game = "duel"
+	payload, _ := json.Marshal(shot)
+
+	if err := client.Publish(ctx, "player_events", payload); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	// The game of the event is submitted along, for the master to drop the events of other games.
+	if got := <-backend.submitted; got.Type != game.EventShot || string(got.Data) != string(shot.Data) || got.Game != "duel" {
+		t.Fatalf("unexpected submitted event %s %s of game %q", got.Type, got.Data, got.Game)
+	}
+}
+
+func TestServerStatusCodes(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{game.ErrGameAlreadyStarted, codes.FailedPrecondition},
+		{game.ErrGameFinished, codes.FailedPrecondition},
+		{game.ErrInvalidPlayerRegistration, codes.InvalidArgument},
+		{io.ErrUnexpectedEOF, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		client := serve(t, &fakeBackend{err: tt.err})
+
+		_, err := client.arena.Join(context.Background(), &cowboysv1.JoinRequest{Name: "Bill", Health: 1, Damage: 1})
+		if got := status.Code(err); got != tt.code {
+			t.Errorf("%v: expected code %s, got %s", tt.err, tt.code, got)
+		}
+	}
+}
+
+func TestServerAbortRequiresAdminToken(t *testing.T) {
+	client := serve(t, &fakeBackend{})
+
+	for token, code := range map[string]codes.Code{
+		"":                     codes.Unauthenticated,
+		"Bearer wrong":         codes.Unauthenticated,
+		"Bearer " + adminToken: codes.OK,
+	} {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+		}
+
+		_, err := client.arena.Abort(ctx, &cowboysv1.AbortRequest{Reason: "closing time"})
+		if got := status.Code(err); got != code {
+			t.Errorf("%q: expected code %s, got %s", token, code, got)
+		}
+	}
+
+	// The players do not need the token.
+	if _, err := client.arena.GetState(context.Background(), &cowboysv1.GetStateRequest{}); err != nil {
+		t.Fatalf("unexpected get state err: %v", err)
+	}
+
+	interceptor := Authorize("")
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: cowboysv1.Arena_Abort_FullMethodName},
+		func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected Abort to be refused without an admin token, got: %v", err)
+	}
+}
+
+func TestClientLogsStreamFailures(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	// A server without the arena service, like a wrong address.
+	server := grpc.NewServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	var logs bytes.Buffer
+	client, err := Dial(listener.Addr().String(), log.New(&logs, "", 0))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	subscription := client.Subscribe(ctx, "master_events")
+	defer subscription.Close()
+
+	select {
+	case _, ok := <-subscription.Channel():
+		if ok {
+			t.Fatal("expected no event from a server without the arena service")
+		}
+	case <-ctx.Done():
+		t.Fatal("expected the subscription to close")
+	}
+
+	if !strings.Contains(logs.String(), codes.Unimplemented.String()) {
+		t.Fatalf("expected the stream failure to be logged, got %q", logs.String())
+	}
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	ready, _ := game.NewEvent(game.EventReady, &domain.Ready{ID: "a"})
+
+	message, err := toProto(ready)
+	if err != nil {
+		t.Fatalf("to proto: %v", err)
+	}
+
+	// Clients of other languages send the typed payload only.
+	message.Json = nil
+	message.Payload = &cowboysv1.GameEvent_Ready{Ready: &cowboysv1.Ready{Id: "a"}}
+
+	event, err := fromProto(message)
+	if err != nil {
+		t.Fatalf("from proto: %v", err)
+	}
+
+	if event.Type != game.EventReady || string(event.Data) != string(ready.Data) {
+		t.Fatalf("unexpected event %s %s", event.Type, event.Data)
+	}
+}
@@ -0,0 +1,39 @@
+package grpcapi
+
+import (
+	"fmt"
+
+	cowboysv1 "github.com/reactivejson/cowboys/api/cowboys/v1"
+	"github.com/reactivejson/cowboys/internal/game"
+	"google.golang.org/protobuf/proto"
+)
+
+// MarshalEvent encodes an event as a compact envelope for the Redis topics: the typed payload replaces the JSON
+// data of the event types known to the contract, the others keep their JSON data.
+func MarshalEvent(event *game.Event) ([]byte, error) {
+	message, err := toProto(event)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.Payload != nil {
+		message.Json = nil
+	}
+
+	return proto.Marshal(message)
+}
+
+// UnmarshalEvent decodes an envelope encoded by MarshalEvent.
+func UnmarshalEvent(payload []byte) (*game.Event, error) {
+	var message cowboysv1.GameEvent
+	if err := proto.Unmarshal(payload, &message); err != nil {
+		return nil, fmt.Errorf("unmarshal envelope: %w", err)
+	}
+
+	event, err := fromProto(&message)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
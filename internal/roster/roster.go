@@ -0,0 +1,178 @@
+package roster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// defaultReloadTime is the reload time of a player process without RELOAD_TIME.
+const defaultReloadTime = 2 * time.Second
+
+// Roster lists the cowboys of a game, in the players.json format.
+type Roster struct {
+	Players []*Entry `json:"players"`
+}
+
+// Entry is the configuration of one cowboy. The speed and the weapon fields are optional.
+type Entry struct {
+	Name   string `json:"name"`
+	Health int    `json:"health"`
+	Damage int    `json:"damage"`
+	// Speed is the number of shots per second, 1 when omitted.
+	Speed float64 `json:"speed,omitempty"`
+
+	Weapon     string  `json:"weapon,omitempty"`
+	Magazine   int     `json:"magazine,omitempty"`
+	ReloadTime string  `json:"reload_time,omitempty"`
+	FireRate   float64 `json:"fire_rate,omitempty"`
+	// Effects are the on-hit effects of the weapon, written kind:magnitude:rounds[:chance[:self]].
+	Effects string `json:"effects,omitempty"`
+
+	// Allies are the names of the cowboys the cowboy proposes an alliance to.
+	Allies          []string `json:"allies,omitempty"`
+	AcceptAlliances bool     `json:"accept_alliances,omitempty"`
+}
+
+// AllyNames returns the allies of the cowboy, comma separated like in ALLIES.
+func (e *Entry) AllyNames() string {
+	return strings.Join(e.Allies, ",")
+}
+
+// Env returns the environment variables configuring the player process of the cowboy.
+func (e *Entry) Env() []string {
+	env := []string{
+		"NAME=" + e.Name,
+		"HEALTH=" + strconv.Itoa(e.Health),
+		"DAMAGE=" + strconv.Itoa(e.Damage),
+	}
+
+	if e.Speed > 0 {
+		env = append(env, "SPEED="+strconv.FormatFloat(e.Speed, 'f', -1, 64))
+	}
+
+	if e.Weapon != "" {
+		env = append(env, "WEAPON="+e.Weapon)
+	}
+
+	if e.Magazine > 0 {
+		env = append(env, "MAGAZINE="+strconv.Itoa(e.Magazine))
+	}
+
+	if e.ReloadTime != "" {
+		env = append(env, "RELOAD_TIME="+e.ReloadTime)
+	}
+
+	if e.FireRate > 0 {
+		env = append(env, "FIRE_RATE="+strconv.FormatFloat(e.FireRate, 'f', -1, 64))
+	}
+
+	if e.Effects != "" {
+		env = append(env, "WEAPON_EFFECTS="+e.Effects)
+	}
+
+	if len(e.Allies) > 0 {
+		env = append(env, "ALLIES="+e.AllyNames())
+	}
+
+	if e.AcceptAlliances {
+		env = append(env, "ACCEPT_ALLIANCES=true")
+	}
+
+	return env
+}
+
+// Player returns the cowboy the entry registers, identified by its name. The entry must be valid.
+func (e *Entry) Player() *domain.Player {
+	reload := defaultReloadTime
+	if e.ReloadTime != "" {
+		reload, _ = time.ParseDuration(e.ReloadTime)
+	}
+
+	effects, _ := domain.ParseOnHits(e.Effects)
+
+	return &domain.Player{
+		ID:     e.Name,
+		Name:   e.Name,
+		Health: e.Health,
+		Damage: e.Damage,
+		Speed:  e.Speed,
+		Weapon: &domain.Weapon{
+			Name:       e.Weapon,
+			Damage:     e.Damage,
+			Magazine:   e.Magazine,
+			ReloadTime: reload,
+			FireRate:   e.FireRate,
+			Effects:    effects,
+		},
+	}
+}
+
+// Load reads and validates a roster file.
+func Load(path string) (*Roster, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open roster: %w", err)
+	}
+	defer file.Close()
+
+	var roster Roster
+	if err := json.NewDecoder(file).Decode(&roster); err != nil {
+		return nil, fmt.Errorf("decode roster %s: %w", path, err)
+	}
+
+	if err := roster.Validate(); err != nil {
+		return nil, fmt.Errorf("roster %s: %w", path, err)
+	}
+
+	return &roster, nil
+}
+
+// Validate checks that the roster can make a game: at least two cowboys with unique names,
+// positive health and damage.
+func (r *Roster) Validate() error {
+	if len(r.Players) < 2 {
+		return fmt.Errorf("a game needs at least 2 cowboys, got %d", len(r.Players))
+	}
+
+	names := make(map[string]bool, len(r.Players))
+	for _, entry := range r.Players {
+		switch {
+		case entry.Name == "":
+			return fmt.Errorf("cowboy without a name")
+		case names[entry.Name]:
+			return fmt.Errorf("duplicate cowboy %q", entry.Name)
+		case entry.Health < 1 || entry.Damage < 1:
+			return fmt.Errorf("cowboy %q needs positive health and damage", entry.Name)
+		case entry.Speed < 0 || entry.Magazine < 0 || entry.FireRate < 0:
+			return fmt.Errorf("cowboy %q can not have a negative speed, magazine or fire rate", entry.Name)
+		}
+
+		if entry.ReloadTime != "" {
+			if reload, err := time.ParseDuration(entry.ReloadTime); err != nil || reload < 0 {
+				return fmt.Errorf("cowboy %q has an invalid reload time %q", entry.Name, entry.ReloadTime)
+			}
+		}
+
+		if _, err := domain.ParseOnHits(entry.Effects); err != nil {
+			return fmt.Errorf("cowboy %q: %w", entry.Name, err)
+		}
+
+		names[entry.Name] = true
+	}
+
+	for _, entry := range r.Players {
+		for _, ally := range entry.Allies {
+			if ally == entry.Name || !names[ally] {
+				return fmt.Errorf("cowboy %q can not ally with %q", entry.Name, ally)
+			}
+		}
+	}
+
+	return nil
+}
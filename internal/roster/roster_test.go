@@ -0,0 +1,39 @@
+package roster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	roster, err := Load(filepath.Join("..", "..", "players.json"))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if len(roster.Players) != 4 || roster.Players[0].Name != "p1" || roster.Players[0].Health != 10 || roster.Players[0].Damage != 3 {
+		t.Fatalf("unexpected roster %+v", roster.Players)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"malformed": `{"players": [`,
+		"alone":     `{"players": [{"name": "p1", "health": 1, "damage": 1}]}`,
+		"duplicate": `{"players": [{"name": "p1", "health": 1, "damage": 1}, {"name": "p1", "health": 1, "damage": 1}]}`,
+		"harmless":  `{"players": [{"name": "p1", "health": 1, "damage": 0}, {"name": "p2", "health": 1, "damage": 1}]}`,
+		"backwards": `{"players": [{"name": "p1", "health": 1, "damage": 1, "speed": -1}, {"name": "p2", "health": 1, "damage": 1}]}`,
+		"loner":     `{"players": [{"name": "p1", "health": 1, "damage": 1, "allies": ["p3"]}, {"name": "p2", "health": 1, "damage": 1}]}`,
+		"cursed":    `{"players": [{"name": "p1", "health": 1, "damage": 1, "effects": "curse:1:1"}, {"name": "p2", "health": 1, "damage": 1}]}`,
+	} {
+		path := filepath.Join(t.TempDir(), "players.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write roster: %v", err)
+		}
+
+		if _, err := Load(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package strategy
+
+import (
+	"context"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// Cautious shoots at the weakest opponent when the shot finishes it. Otherwise it heals once its health fell
+// to half, protects itself when an opponent could kill it with a shot, taking cover when that saves it and
+// dodging otherwise, and aims when only an aimed shot would finish its target.
+type Cautious struct{}
+
+func (Cautious) Target(ctx context.Context, self string, round *domain.Round) (string, error) {
+	return Weakest{}.Target(ctx, self, round)
+}
+
+func (c Cautious) Decide(ctx context.Context, self string, round *domain.Round) (*domain.Action, error) {
+	target, err := c.Target(ctx, self, round)
+	if err != nil {
+		return nil, err
+	}
+
+	shot := &domain.Action{Src: self, Dest: target}
+
+	me, ok := round.Players[self]
+	if !ok {
+		return shot, nil
+	}
+
+	aiming := hasEffect(me, domain.EffectAim)
+	damage, health := shotDamage(me, round), round.Players[target].Health
+	if aiming {
+		damage *= domain.AimMultiplier
+	}
+
+	threat := 0
+	for _, id := range Opponents(self, round) {
+		if opponent := shotDamage(round.Players[id], round); opponent > threat {
+			threat = opponent
+		}
+	}
+
+	switch {
+	case health <= damage:
+		return shot, nil
+	case me.Heals > 0 && me.Health*2 <= me.MaxHealth:
+		return &domain.Action{Src: self, Kind: domain.ActionHeal}, nil
+	case threat >= me.Health && (threat+1)/2 < me.Health:
+		return &domain.Action{Src: self, Kind: domain.ActionCover}, nil
+	case threat >= me.Health:
+		return &domain.Action{Src: self, Kind: domain.ActionDodge}, nil
+	case !aiming && health <= damage*domain.AimMultiplier:
+		return &domain.Action{Src: self, Kind: domain.ActionAim}, nil
+	default:
+		return shot, nil
+	}
+}
+
+// shotDamage is the damage of a plain shot of the player in the round.
+func shotDamage(player *domain.Player, round *domain.Round) int {
+	if round.DamageMultiplier > 0 {
+		return player.Damage * round.DamageMultiplier
+	}
+
+	return player.Damage
+}
+
+func hasEffect(player *domain.Player, kind domain.EffectKind) bool {
+	for _, effect := range player.Effects {
+		if effect.Kind == kind {
+			return true
+		}
+	}
+
+	return false
+}
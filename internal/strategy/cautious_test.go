@@ -0,0 +1,71 @@
+package strategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+func TestCautious(t *testing.T) {
+	for name, test := range map[string]struct {
+		setup    func(round *domain.Round)
+		expected domain.ActionKind
+	}{
+		// test_2 has 2 health, only an aimed shot of 1 damage finishes it.
+		"aim": {
+			setup:    func(*domain.Round) {},
+			expected: domain.ActionAim,
+		},
+		"aimed shot": {
+			setup: func(round *domain.Round) {
+				round.Players["test_1"].Effects = []*domain.Effect{{Kind: domain.EffectAim, Rounds: 1}}
+			},
+			expected: domain.ActionShoot,
+		},
+		"heal": {
+			setup: func(round *domain.Round) {
+				round.Players["test_1"].Health, round.Players["test_1"].MaxHealth, round.Players["test_1"].Heals = 2, 5, 1
+			},
+			expected: domain.ActionHeal,
+		},
+		"cover": {
+			setup: func(round *domain.Round) {
+				round.Players["test_1"].Health = 3
+				round.Players["test_3"].Damage = 3
+			},
+			expected: domain.ActionCover,
+		},
+		"dodge": {
+			setup: func(round *domain.Round) {
+				round.Players["test_1"].Health = 3
+				round.Players["test_3"].Damage = 6
+			},
+			expected: domain.ActionDodge,
+		},
+		"finishing shot": {
+			setup: func(round *domain.Round) {
+				round.Players["test_1"].Health = 3
+				round.Players["test_2"].Health = 1
+				round.Players["test_3"].Damage = 6
+			},
+			expected: domain.ActionShoot,
+		},
+	} {
+		round := testRound()
+		test.setup(round)
+
+		action, err := Cautious{}.Decide(context.Background(), "test_1", round)
+		if err != nil {
+			t.Fatalf("%s: unexpected err: %v", name, err)
+		}
+
+		if action.Type() != test.expected || action.Validate() != nil {
+			t.Errorf("%s: expected to %s, got %+v", name, test.expected, action)
+		}
+
+		if test.expected == domain.ActionShoot && action.Dest != "test_2" {
+			t.Errorf("%s: expected a shot at test_2, got %+v", name, action)
+		}
+	}
+}
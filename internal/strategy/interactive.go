@@ -0,0 +1,161 @@
+package strategy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+const (
+	clearScreen = "\033[H\033[2J"
+	healthBar   = 20
+)
+
+// Interactive renders every round in the terminal and lets a human pick the target with the keyboard.
+// When nobody picks a valid target before the deadline, the fallback strategy decides.
+type Interactive struct {
+	out      io.Writer
+	lines    chan string
+	timeout  time.Duration
+	fallback Strategy
+}
+
+// NewInteractive reads the choices line by line from in and renders the rounds to out.
+func NewInteractive(in io.Reader, out io.Writer, timeout time.Duration, fallback Strategy) *Interactive {
+	i := &Interactive{
+		out:      out,
+		lines:    make(chan string, 16),
+		timeout:  timeout,
+		fallback: fallback,
+	}
+
+	go i.scan(in)
+
+	return i
+}
+
+func (i *Interactive) scan(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		i.lines <- strings.TrimSpace(scanner.Text())
+	}
+
+	close(i.lines)
+}
+
+func (i *Interactive) Target(ctx context.Context, self string, round *domain.Round) (string, error) {
+	opponents := Opponents(self, round)
+	if len(opponents) == 0 {
+		return "", ErrNoTarget
+	}
+
+	i.drain()
+	i.render(self, round, opponents)
+
+	deadline := time.NewTimer(i.timeout)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case line, ok := <-i.lines:
+			if !ok {
+				i.lines = nil
+				continue
+			}
+
+			if target, ok := choose(line, opponents, round); ok {
+				fmt.Fprintf(i.out, "Shooting at %s!\n", round.Players[target].Name)
+				return target, nil
+			}
+
+			fmt.Fprintf(i.out, "No such opponent %q, pick a number between 1 and %d: ", line, len(opponents))
+		case <-deadline.C:
+			fmt.Fprintln(i.out, "\nToo slow, your trigger finger decides.")
+			return i.fallback.Target(ctx, self, round)
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// drain discards what was typed while no round was waiting for a choice.
+func (i *Interactive) drain() {
+	for {
+		select {
+		case _, ok := <-i.lines:
+			if !ok {
+				i.lines = nil
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (i *Interactive) render(self string, round *domain.Round, opponents []string) {
+	var b strings.Builder
+
+	b.WriteString(clearScreen)
+	fmt.Fprintf(&b, "Round %d", round.Number)
+	if round.DamageMultiplier > 0 {
+		fmt.Fprintf(&b, " - SUDDEN DEATH x%d", round.DamageMultiplier)
+	}
+
+	b.WriteString("\n\n")
+
+	if me, ok := round.Players[self]; ok {
+		fmt.Fprintf(&b, "    You: %-12s %s %3d hp  %2d dmg\n\n", me.Name, bar(me.Health, round), me.Health, me.Damage)
+	}
+
+	for n, id := range opponents {
+		player := round.Players[id]
+		fmt.Fprintf(&b, "%3d) %-14s %s %3d hp  %2d dmg\n", n+1, player.Name, bar(player.Health, round), player.Health, player.Damage)
+	}
+
+	fmt.Fprintf(&b, "\nPick a target [1-%d] within %s: ", len(opponents), i.timeout)
+
+	_, _ = io.WriteString(i.out, b.String())
+}
+
+// choose resolves a line typed by the human, either the number in the list or the cowboy name.
+func choose(line string, opponents []string, round *domain.Round) (string, bool) {
+	if n, err := strconv.Atoi(line); err == nil {
+		if n < 1 || n > len(opponents) {
+			return "", false
+		}
+
+		return opponents[n-1], true
+	}
+
+	for _, id := range opponents {
+		if strings.EqualFold(round.Players[id].Name, line) {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// bar draws the health relative to the healthiest cowboy of the round.
+func bar(health int, round *domain.Round) string {
+	top := 1
+	for _, player := range round.Players {
+		if player.Health > top {
+			top = player.Health
+		}
+	}
+
+	filled := health * healthBar / top
+	if filled < 0 {
+		filled = 0
+	}
+
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", healthBar-filled) + "]"
+}
@@ -0,0 +1,69 @@
+package strategy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInteractive(t *testing.T) {
+	in, keyboard := io.Pipe()
+	defer keyboard.Close()
+
+	var out bytes.Buffer
+	interactive := NewInteractive(in, &out, time.Second, Weakest{})
+
+	go func() {
+		_, _ = io.WriteString(keyboard, "test3\n")
+	}()
+
+	// Opponents are listed sorted by ID: 1) test_2, 2) test_3.
+	target, err := interactive.Target(context.Background(), "test_1", testRound())
+	if err != nil || target != "test_3" {
+		t.Fatalf("expected chosen target test_3, got %q (%v)", target, err)
+	}
+
+	rendered := out.String()
+	for _, want := range []string{"Round 3", "You: Test1", "1) Test2", "2) Test3"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("expected %q in rendered round:\n%s", want, rendered)
+		}
+	}
+}
+
+func TestInteractiveFallback(t *testing.T) {
+	in, keyboard := io.Pipe()
+	defer keyboard.Close()
+
+	interactive := NewInteractive(in, io.Discard, 20*time.Millisecond, Weakest{})
+
+	target, err := interactive.Target(context.Background(), "test_1", testRound())
+	if err != nil || target != "test_2" {
+		t.Fatalf("expected fallback target test_2, got %q (%v)", target, err)
+	}
+}
+
+func TestInteractiveChoose(t *testing.T) {
+	round := testRound()
+	opponents := Opponents("test_1", round)
+
+	tests := map[string]string{
+		"1":     "test_2",
+		"2":     "test_3",
+		"TEST2": "test_2",
+		"0":     "",
+		"3":     "",
+		"Test1": "",
+		"bang":  "",
+	}
+
+	for line, want := range tests {
+		target, ok := choose(line, opponents, round)
+		if target != want || ok != (want != "") {
+			t.Fatalf("choose(%q) = %q, %v, expected %q", line, target, ok, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package strategy
+
+import (
+	"context"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// NearestMinHitChance is the hit chance below which Nearest steps towards its target rather than shooting.
+const NearestMinHitChance = 0.5
+
+// Nearest shoots at the closest opponent but its allies in a positional game, and steps towards it, taking cover when it can,
+// while the shot would likely miss. Without a grid it shoots at the weakest opponent.
+type Nearest struct{}
+
+func (n Nearest) Target(ctx context.Context, self string, round *domain.Round) (string, error) {
+	me, ok := round.Players[self]
+	if round.Grid == nil || !ok || me.Position == nil {
+		return Weakest{}.Target(ctx, self, round)
+	}
+
+	opponents := Targets(self, round)
+	if len(opponents) == 0 {
+		return "", ErrNoTarget
+	}
+
+	target := opponents[0]
+	for _, id := range opponents[1:] {
+		if me.Position.Distance(*round.Players[id].Position) < me.Position.Distance(*round.Players[target].Position) {
+			target = id
+		}
+	}
+
+	return target, nil
+}
+
+func (n Nearest) Decide(ctx context.Context, self string, round *domain.Round) (*domain.Action, error) {
+	target, err := n.Target(ctx, self, round)
+	if err != nil {
+		return nil, err
+	}
+
+	action := &domain.Action{Src: self, Dest: target}
+
+	me := round.Players[self]
+	if round.Grid == nil || me.Position == nil {
+		return action, nil
+	}
+
+	to := *round.Players[target].Position
+	if round.Grid.HitChance(*me.Position, to) >= NearestMinHitChance {
+		return action, nil
+	}
+
+	if step, ok := approach(round, *me.Position, to); ok {
+		return &domain.Action{Src: self, Move: &step}, nil
+	}
+
+	return action, nil
+}
+
+// approach returns the free adjacent tile closest to the target, a cover tile on a tie.
+// It fails when no step gets closer.
+func approach(round *domain.Round, from, to domain.Position) (domain.Position, bool) {
+	best, found := from, false
+
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			step := domain.Position{X: from.X + dx, Y: from.Y + dy}
+			if step == from || !round.Grid.Contains(step) || occupied(round, step) {
+				continue
+			}
+
+			distance, bestDistance := step.Distance(to), best.Distance(to)
+			if distance < bestDistance || (found && distance == bestDistance && round.Grid.IsCover(step) && !round.Grid.IsCover(best)) {
+				best, found = step, true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// occupied reports whether a cowboy stands on the tile.
+func occupied(round *domain.Round, tile domain.Position) bool {
+	for _, player := range round.Players {
+		if player.Position != nil && *player.Position == tile {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,56 @@
+package strategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+func positionalRound() *domain.Round {
+	round := testRound()
+	round.Grid = &domain.Grid{Width: 10, Height: 10, Cover: []domain.Position{{X: 1, Y: 1}}}
+	round.Players["test_1"].Position = &domain.Position{X: 0, Y: 0}
+	round.Players["test_2"].Position = &domain.Position{X: 9, Y: 9}
+	round.Players["test_3"].Position = &domain.Position{X: 0, Y: 3}
+
+	return round
+}
+
+func TestNearest(t *testing.T) {
+	target, err := Nearest{}.Target(context.Background(), "test_1", positionalRound())
+	if err != nil || target != "test_3" {
+		t.Fatalf("expected nearest target test_3, got %q (%v)", target, err)
+	}
+
+	// Without a grid, the nearest strategy finishes off the weakest.
+	target, err = Nearest{}.Target(context.Background(), "test_1", testRound())
+	if err != nil || target != "test_2" {
+		t.Fatalf("expected weakest target test_2, got %q (%v)", target, err)
+	}
+}
+
+func TestNearestDecide(t *testing.T) {
+	// test_3 is 3 tiles away, an 80% chance: test_1 shoots.
+	action, err := Nearest{}.Decide(context.Background(), "test_1", positionalRound())
+	if err != nil || action.Move != nil || action.Dest != "test_3" {
+		t.Fatalf("expected a shot at test_3, got %+v (%v)", action, err)
+	}
+
+	// test_3 is out of reach for test_2, which steps towards it and takes cover on the way.
+	round := positionalRound()
+	round.Players["test_2"].Position = &domain.Position{X: 2, Y: 9}
+	round.Grid.Cover = append(round.Grid.Cover, domain.Position{X: 1, Y: 8})
+
+	action, err = Nearest{}.Decide(context.Background(), "test_2", round)
+	if err != nil || action.Move == nil || *action.Move != (domain.Position{X: 1, Y: 8}) {
+		t.Fatalf("expected a step to the cover at 1:8, got %+v (%v)", action, err)
+	}
+}
+
+func TestDecide(t *testing.T) {
+	action, err := Decide(context.Background(), Weakest{}, "test_1", positionalRound())
+	if err != nil || action.Src != "test_1" || action.Dest != "test_2" || action.Move != nil {
+		t.Fatalf("expected a shot at the weakest, got %+v (%v)", action, err)
+	}
+}
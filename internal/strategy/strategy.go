@@ -0,0 +1,152 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// Names of the built-in strategies.
+const (
+	NameRandom   = "random"
+	NameWeakest  = "weakest"
+	NameNearest  = "nearest"
+	NameCautious = "cautious"
+)
+
+var ErrNoTarget = fmt.Errorf("no target found")
+
+// Strategy picks the cowboy to shoot at in a round.
+type Strategy interface {
+	// Target returns the ID of the cowboy that self shoots at in the round.
+	Target(ctx context.Context, self string, round *domain.Round) (string, error)
+}
+
+// Decider is a strategy that decides the whole action of the cowboy, to move in a positional game
+// or to take an action other than a shot.
+type Decider interface {
+	Strategy
+	// Decide returns the action of self in the round.
+	Decide(ctx context.Context, self string, round *domain.Round) (*domain.Action, error)
+}
+
+// Decide returns the action of self in the round: the one of a Decider, a shot at the target of other strategies.
+func Decide(ctx context.Context, strategy Strategy, self string, round *domain.Round) (*domain.Action, error) {
+	if decider, ok := strategy.(Decider); ok {
+		return decider.Decide(ctx, self, round)
+	}
+
+	target, err := strategy.Target(ctx, self, round)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Action{Src: self, Dest: target}, nil
+}
+
+// New returns the built-in strategy registered under name.
+func New(name string) (Strategy, error) {
+	switch name {
+	case NameRandom, "":
+		return NewRandom(time.Now().UnixNano()), nil
+	case NameWeakest:
+		return Weakest{}, nil
+	case NameNearest:
+		return Nearest{}, nil
+	case NameCautious:
+		return Cautious{}, nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", name)
+	}
+}
+
+// Random shoots at a random opponent, sparing its allies.
+type Random struct {
+	rnd  *rand.Rand
+	lock sync.Mutex
+}
+
+// NewRandom creates a random strategy, deterministic for a given seed.
+func NewRandom(seed int64) *Random {
+	return &Random{rnd: rand.New(rand.NewSource(seed))}
+}
+
+func (r *Random) Target(_ context.Context, self string, round *domain.Round) (string, error) {
+	opponents := Targets(self, round)
+	if len(opponents) == 0 {
+		return "", ErrNoTarget
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return opponents[r.rnd.Intn(len(opponents))], nil
+}
+
+// Weakest shoots at the opponent with the lowest health, to finish it off, sparing its allies.
+type Weakest struct{}
+
+func (Weakest) Target(_ context.Context, self string, round *domain.Round) (string, error) {
+	opponents := Targets(self, round)
+	if len(opponents) == 0 {
+		return "", ErrNoTarget
+	}
+
+	target := opponents[0]
+	for _, id := range opponents[1:] {
+		if round.Players[id].Health < round.Players[target].Health {
+			target = id
+		}
+	}
+
+	return target, nil
+}
+
+// Opponents returns the IDs of the living cowboys self can shoot at, sorted for determinism.
+func Opponents(self string, round *domain.Round) []string {
+	opponents := make([]string, 0, len(round.Players))
+	for id := range round.Players {
+		if id != self {
+			opponents = append(opponents, id)
+		}
+	}
+
+	sort.Strings(opponents)
+
+	return opponents
+}
+
+// Targets returns the opponents the built-in strategies shoot at: the ones that are not allied with self,
+// or all of them once only allies are left.
+func Targets(self string, round *domain.Round) []string {
+	opponents := Opponents(self, round)
+
+	me, ok := round.Players[self]
+	if !ok || len(me.Allies) == 0 {
+		return opponents
+	}
+
+	targets := make([]string, 0, len(opponents))
+	for _, id := range opponents {
+		if !me.IsAlly(id) {
+			targets = append(targets, id)
+		}
+	}
+
+	if len(targets) == 0 {
+		return opponents
+	}
+
+	return targets
+}
+
+// IsOpponent reports whether target is a living cowboy self can shoot at.
+func IsOpponent(self, target string, round *domain.Round) bool {
+	_, ok := round.Players[target]
+	return ok && target != self
+}
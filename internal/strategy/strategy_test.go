@@ -0,0 +1,163 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+func testRound() *domain.Round {
+	return &domain.Round{
+		Players: map[string]*domain.Player{
+			"test_1": {ID: "test_1", Name: "Test1", Health: 5, Damage: 1},
+			"test_2": {ID: "test_2", Name: "Test2", Health: 2, Damage: 1},
+			"test_3": {ID: "test_3", Name: "Test3", Health: 4, Damage: 1},
+		},
+		Number: 3,
+	}
+}
+
+func TestWeakest(t *testing.T) {
+	target, err := Weakest{}.Target(context.Background(), "test_1", testRound())
+	if err != nil || target != "test_2" {
+		t.Fatalf("expected weakest target test_2, got %q (%v)", target, err)
+	}
+
+	target, err = Weakest{}.Target(context.Background(), "test_2", testRound())
+	if err != nil || target != "test_3" {
+		t.Fatalf("expected weakest target test_3, got %q (%v)", target, err)
+	}
+}
+
+func TestWeakestSparesAllies(t *testing.T) {
+	round := testRound()
+	round.Players["test_1"].Allies = []string{"test_2"}
+
+	target, err := Weakest{}.Target(context.Background(), "test_1", round)
+	if err != nil || target != "test_3" {
+		t.Fatalf("expected test_1 to spare its ally test_2, got %q (%v)", target, err)
+	}
+
+	// Once only allies are left, they are fair game.
+	delete(round.Players, "test_3")
+	target, err = Weakest{}.Target(context.Background(), "test_1", round)
+	if err != nil || target != "test_2" {
+		t.Fatalf("expected test_1 to turn on test_2, got %q (%v)", target, err)
+	}
+}
+
+func TestRandomNeverShootsItself(t *testing.T) {
+	random := NewRandom(1)
+	for i := 0; i < 100; i++ {
+		target, err := random.Target(context.Background(), "test_1", testRound())
+		if err != nil || !IsOpponent("test_1", target, testRound()) {
+			t.Fatalf("unexpected target %q (%v)", target, err)
+		}
+	}
+
+	alone := &domain.Round{Players: map[string]*domain.Player{"test_1": {ID: "test_1"}}}
+	if _, err := random.Target(context.Background(), "test_1", alone); err != ErrNoTarget {
+		t.Fatalf("expected ErrNoTarget, got: %v", err)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request WebhookRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("unexpected webhook request body: %v", err)
+		}
+
+		if request.Self != "test_1" || request.Round.Number != 3 {
+			t.Errorf("unexpected webhook request %+v", request)
+		}
+
+		_ = json.NewEncoder(w).Encode(&WebhookResponse{Target: "test_3"})
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, time.Second, Weakest{}, log.New(io.Discard, "", 0))
+
+	target, err := webhook.Target(context.Background(), "test_1", testRound())
+	if err != nil || target != "test_3" {
+		t.Fatalf("expected remote target test_3, got %q (%v)", target, err)
+	}
+}
+
+func TestWebhookMove(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&WebhookResponse{Move: &domain.Position{X: 1, Y: 0}})
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, time.Second, Weakest{}, log.New(io.Discard, "", 0))
+
+	action, err := webhook.Decide(context.Background(), "test_1", positionalRound())
+	if err != nil || action.Move == nil || *action.Move != (domain.Position{X: 1, Y: 0}) {
+		t.Fatalf("expected a remote move to 1:0, got %+v (%v)", action, err)
+	}
+
+	// A move makes no sense without a grid.
+	action, err = webhook.Decide(context.Background(), "test_1", testRound())
+	if err != nil || action.Move != nil || action.Dest != "test_2" {
+		t.Fatalf("expected a fallback shot at test_2, got %+v (%v)", action, err)
+	}
+}
+
+func TestWebhookFallback(t *testing.T) {
+	tests := map[string]http.HandlerFunc{
+		"timeout": func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		},
+		"error": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		},
+		"self target": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(&WebhookResponse{Target: "test_1"})
+		},
+		"dead target": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(&WebhookResponse{Target: "test_4"})
+		},
+	}
+
+	for name, handler := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			webhook := NewWebhook(server.URL, 50*time.Millisecond, Weakest{}, log.New(io.Discard, "", 0))
+
+			target, err := webhook.Target(context.Background(), "test_1", testRound())
+			if err != nil || target != "test_2" {
+				t.Fatalf("expected fallback target test_2, got %q (%v)", target, err)
+			}
+		})
+	}
+}
+
+func TestWebhookAction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&WebhookResponse{Action: domain.ActionDodge})
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL, time.Second, Weakest{}, log.New(io.Discard, "", 0))
+
+	action, err := webhook.Decide(context.Background(), "test_1", testRound())
+	if err != nil || action.Type() != domain.ActionDodge || action.Src != "test_1" {
+		t.Fatalf("expected a remote dodge, got %+v (%v)", action, err)
+	}
+
+	// A strategy asked for a target only falls back on another action.
+	target, err := webhook.Target(context.Background(), "test_1", testRound())
+	if err != nil || target != "test_2" {
+		t.Fatalf("expected a fallback target test_2, got %q (%v)", target, err)
+	}
+}
@@ -0,0 +1,125 @@
+package strategy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// WebhookRequest is the body POSTed to the remote strategy every round.
+type WebhookRequest struct {
+	Self  string        `json:"self"`
+	Round *domain.Round `json:"round"`
+}
+
+// WebhookResponse is the answer expected from the remote strategy: the target, in a positional
+// game the tile to step to, or another action.
+type WebhookResponse struct {
+	Target string           `json:"target,omitempty"`
+	Move   *domain.Position `json:"move,omitempty"`
+	// Action is dodge, aim, heal or cover, a shot at the target or the move when empty.
+	Action domain.ActionKind `json:"action,omitempty"`
+}
+
+// Webhook asks an external service for the action. When the service fails, times out or
+// answers with a cowboy that can not be shot, the fallback strategy decides.
+type Webhook struct {
+	url      string
+	timeout  time.Duration
+	fallback Strategy
+	client   *http.Client
+	logger   *log.Logger
+}
+
+func NewWebhook(url string, timeout time.Duration, fallback Strategy, logger *log.Logger) *Webhook {
+	return &Webhook{
+		url:      url,
+		timeout:  timeout,
+		fallback: fallback,
+		client:   http.DefaultClient,
+		logger:   logger,
+	}
+}
+
+func (w *Webhook) Target(ctx context.Context, self string, round *domain.Round) (string, error) {
+	action, err := w.ask(ctx, self, round)
+	if err == nil && action.Type() != domain.ActionShoot {
+		err = fmt.Errorf("%s answered where a target is expected", action.Type())
+	}
+
+	if err != nil {
+		w.logger.Printf("remote strategy, falling back: %v", err)
+		return w.fallback.Target(ctx, self, round)
+	}
+
+	return action.Dest, nil
+}
+
+func (w *Webhook) Decide(ctx context.Context, self string, round *domain.Round) (*domain.Action, error) {
+	action, err := w.ask(ctx, self, round)
+	if err != nil {
+		w.logger.Printf("remote strategy, falling back: %v", err)
+		return Decide(ctx, w.fallback, self, round)
+	}
+
+	return action, nil
+}
+
+func (w *Webhook) ask(ctx context.Context, self string, round *domain.Round) (*domain.Action, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	payload, err := json.Marshal(&WebhookRequest{
+		Self:  self,
+		Round: round,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal webhook request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected webhook response code %d", resp.StatusCode)
+	}
+
+	var answer WebhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return nil, fmt.Errorf("decode webhook response: %w", err)
+	}
+
+	action := &domain.Action{Src: self, Dest: answer.Target, Move: answer.Move, Kind: answer.Action}
+	if err := action.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid action: %w", err)
+	}
+
+	switch action.Type() {
+	case domain.ActionMove:
+		if round.Grid == nil {
+			return nil, fmt.Errorf("move answered outside of a positional game")
+		}
+	case domain.ActionShoot:
+		if !IsOpponent(self, answer.Target, round) {
+			return nil, fmt.Errorf("invalid target %q", answer.Target)
+		}
+	}
+
+	return action, nil
+}
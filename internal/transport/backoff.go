@@ -0,0 +1,38 @@
+package transport
+
+import (
+	"math/rand"
+	"time"
+)
+
+// backoff is an exponential backoff with jitter: the delay doubles with every attempt up to the maximum, and a
+// random half of it is jitter, so that the processes of a game do not retry in lockstep. It is not safe for
+// concurrent use.
+type backoff struct {
+	base time.Duration
+	max  time.Duration
+	rnd  *rand.Rand
+}
+
+// newBackoff returns a backoff starting at base, or at fallback when base is not positive.
+func newBackoff(base, max, fallback time.Duration) *backoff {
+	if base <= 0 {
+		base = fallback
+	}
+
+	if max < base {
+		max = base
+	}
+
+	return &backoff{base: base, max: max, rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// delay is the backoff before the retry following the given attempt, counted from 0.
+func (b *backoff) delay(attempt int) time.Duration {
+	delay := b.max
+	if attempt < 32 && b.base<<attempt < b.max {
+		delay = b.base << attempt
+	}
+
+	return delay/2 + time.Duration(b.rnd.Int63n(int64(delay/2)+1))
+}
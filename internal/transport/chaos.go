@@ -0,0 +1,308 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AnyTopic configures the faults of the topics without their own configuration.
+const AnyTopic = "*"
+
+// reorderWindow bounds how long a held back message waits for the next one before it is sent anyway.
+const reorderWindow = time.Second
+
+// Faults are the rates, between 0 and 1, at which published messages are tampered with.
+type Faults struct {
+	Drop      float64 `json:"drop"`
+	Duplicate float64 `json:"duplicate"`
+	Corrupt   float64 `json:"corrupt"`
+	// Reorder holds a message back and sends it after the next one on the same topic.
+	Reorder float64 `json:"reorder"`
+	// Delay sends a message DelayFor later.
+	Delay    float64  `json:"delay"`
+	DelayFor Duration `json:"delay_for"`
+}
+
+// ChaosConfig configures the faults per topic, and an optional partition cutting the process off the bus.
+type ChaosConfig struct {
+	Topics         map[string]Faults `json:"topics"`
+	PartitionAfter Duration          `json:"partition_after"`
+	PartitionFor   Duration          `json:"partition_for"`
+	Seed           int64             `json:"seed"`
+}
+
+// ParseChaosConfig reads a JSON chaos configuration, for instance
+// {"topics": {"master_events": {"drop": 0.1, "delay": 0.2, "delay_for": "300ms"}}}.
+func ParseChaosConfig(raw string) (*ChaosConfig, error) {
+	var cfg ChaosConfig
+	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal chaos config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Duration is a time.Duration written as a string like "300ms" in JSON.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+// Chaos wraps a transport to inject faults into the messages the process publishes: drops, delays,
+// duplicates, reordering and corrupted payloads. While partitioned, the process neither sends nor
+// receives anything.
+type Chaos struct {
+	inner  Transport
+	topics map[string]Faults
+	logger *log.Logger
+
+	lock        sync.Mutex
+	rnd         *rand.Rand
+	partitioned bool
+	held        map[string]*heldMessage
+}
+
+type heldMessage struct {
+	payload []byte
+	timer   *time.Timer
+}
+
+func NewChaos(inner Transport, cfg *ChaosConfig, logger *log.Logger) *Chaos {
+	c := &Chaos{
+		inner:  inner,
+		topics: cfg.Topics,
+		logger: logger,
+		rnd:    rand.New(rand.NewSource(cfg.Seed)),
+		held:   make(map[string]*heldMessage),
+	}
+
+	if cfg.PartitionFor > 0 {
+		time.AfterFunc(time.Duration(cfg.PartitionAfter), func() {
+			c.Partition()
+			time.AfterFunc(time.Duration(cfg.PartitionFor), c.Heal)
+		})
+	}
+
+	return c
+}
+
+// Partition cuts the process off the bus.
+func (c *Chaos) Partition() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.logger.Printf("chaos: network partition")
+	c.partitioned = true
+}
+
+// Heal ends the partition.
+func (c *Chaos) Heal() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.logger.Printf("chaos: network partition healed")
+	c.partitioned = false
+}
+
+func (c *Chaos) isPartitioned() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.partitioned
+}
+
+// faults returns the faults of a topic, configured by its full name or by its name without the namespace and
+// the game ID prefixing it.
+func (c *Chaos) faults(topic string) Faults {
+	if faults, ok := c.topics[topic]; ok {
+		return faults
+	}
+
+	if i := strings.LastIndex(topic, ":"); i >= 0 {
+		if faults, ok := c.topics[topic[i+1:]]; ok {
+			return faults
+		}
+	}
+
+	return c.topics[AnyTopic]
+}
+
+// roll draws whether a fault happening at the given rate strikes.
+func (c *Chaos) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.rnd.Float64() < rate
+}
+
+func (c *Chaos) Publish(ctx context.Context, topic string, payload []byte) error {
+	// Like a black hole, a partition loses the messages without an error.
+	if c.isPartitioned() {
+		return nil
+	}
+
+	faults := c.faults(topic)
+
+	if c.roll(faults.Drop) {
+		c.logger.Printf("chaos: drop message on %s", topic)
+		return nil
+	}
+
+	if c.roll(faults.Corrupt) {
+		c.logger.Printf("chaos: corrupt message on %s", topic)
+		payload = corrupt(payload)
+	}
+
+	copies := 1
+	if c.roll(faults.Duplicate) {
+		c.logger.Printf("chaos: duplicate message on %s", topic)
+		copies++
+	}
+
+	if c.roll(faults.Delay) && faults.DelayFor > 0 {
+		c.logger.Printf("chaos: delay message on %s by %s", topic, time.Duration(faults.DelayFor))
+		time.AfterFunc(time.Duration(faults.DelayFor), func() {
+			if err := c.send(context.Background(), topic, payload, copies); err != nil {
+				c.logger.Printf("chaos: publish delayed message on %s: %v", topic, err)
+			}
+		})
+
+		return nil
+	}
+
+	if c.roll(faults.Reorder) && c.hold(topic, payload) {
+		c.logger.Printf("chaos: reorder message on %s", topic)
+		return nil
+	}
+
+	if err := c.send(ctx, topic, payload, copies); err != nil {
+		return err
+	}
+
+	return c.release(ctx, topic)
+}
+
+func (c *Chaos) send(ctx context.Context, topic string, payload []byte, copies int) error {
+	for i := 0; i < copies; i++ {
+		if err := c.inner.Publish(ctx, topic, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hold keeps the payload back until the next message on the topic. Only one message is held per topic.
+func (c *Chaos) hold(topic string, payload []byte) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.held[topic]; ok {
+		return false
+	}
+
+	c.held[topic] = &heldMessage{
+		payload: payload,
+		timer: time.AfterFunc(reorderWindow, func() {
+			if err := c.release(context.Background(), topic); err != nil {
+				c.logger.Printf("chaos: publish held message on %s: %v", topic, err)
+			}
+		}),
+	}
+
+	return true
+}
+
+// release sends the message held back on the topic, if any.
+func (c *Chaos) release(ctx context.Context, topic string) error {
+	c.lock.Lock()
+	held, ok := c.held[topic]
+	delete(c.held, topic)
+	c.lock.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	held.timer.Stop()
+
+	return c.inner.Publish(ctx, topic, held.payload)
+}
+
+func (c *Chaos) Subscribe(ctx context.Context, topic string) Subscription {
+	subscription := &chaosSubscription{
+		inner:    c.inner.Subscribe(ctx, topic),
+		messages: make(chan *Message),
+		done:     make(chan struct{}),
+	}
+
+	go subscription.forward(c)
+
+	return subscription
+}
+
+// chaosSubscription loses the received messages while the process is partitioned.
+type chaosSubscription struct {
+	inner    Subscription
+	messages chan *Message
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (s *chaosSubscription) forward(c *Chaos) {
+	defer close(s.messages)
+
+	for msg := range s.inner.Channel() {
+		if c.isPartitioned() {
+			continue
+		}
+
+		select {
+		case s.messages <- msg:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *chaosSubscription) Channel() <-chan *Message {
+	return s.messages
+}
+
+func (s *chaosSubscription) Close() error {
+	s.once.Do(func() { close(s.done) })
+
+	return s.inner.Close()
+}
+
+// corrupt truncates the payload, which is what a torn message looks like to the receiver.
+func corrupt(payload []byte) []byte {
+	return append([]byte(nil), payload[:len(payload)/2]...)
+}
@@ -0,0 +1,163 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+	"time"
+)
+
+const testTopic = "test_events"
+
+func newChaos(faults Faults) (*Chaos, Subscription) {
+	memory := NewMemory()
+	subscription := memory.Subscribe(context.Background(), testTopic)
+
+	chaos := NewChaos(memory, &ChaosConfig{
+		Topics: map[string]Faults{testTopic: faults},
+		Seed:   1,
+	}, log.New(io.Discard, "", 0))
+
+	return chaos, subscription
+}
+
+func receive(t *testing.T, subscription Subscription) string {
+	t.Helper()
+
+	select {
+	case msg := <-subscription.Channel():
+		return string(msg.Payload)
+	case <-time.After(time.Second):
+		t.Fatalf("no message received")
+		return ""
+	}
+}
+
+func expectNothing(t *testing.T, subscription Subscription) {
+	t.Helper()
+
+	select {
+	case msg := <-subscription.Channel():
+		t.Fatalf("unexpected message %q", msg.Payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func publish(t *testing.T, transport Transport, payload string) {
+	t.Helper()
+
+	if err := transport.Publish(context.Background(), testTopic, []byte(payload)); err != nil {
+		t.Fatalf("unexpected publish err: %v", err)
+	}
+}
+
+func TestChaosDrop(t *testing.T) {
+	chaos, subscription := newChaos(Faults{Drop: 1})
+
+	publish(t, chaos, "bang")
+	expectNothing(t, subscription)
+
+	// Topics without faults are left alone.
+	other := chaos.inner.Subscribe(context.Background(), "other")
+	if err := chaos.Publish(context.Background(), "other", []byte("bang")); err != nil {
+		t.Fatalf("unexpected publish err: %v", err)
+	}
+
+	if got := receive(t, other); got != "bang" {
+		t.Fatalf("unexpected message %q", got)
+	}
+
+	// The faults of a topic apply to it whatever the namespace and the game prefixing it.
+	namespaced := chaos.inner.Subscribe(context.Background(), "cowboys:duel:"+testTopic)
+	if err := chaos.Publish(context.Background(), "cowboys:duel:"+testTopic, []byte("bang")); err != nil {
+		t.Fatalf("unexpected publish err: %v", err)
+	}
+
+	expectNothing(t, namespaced)
+}
+
+func TestChaosDuplicateAndCorrupt(t *testing.T) {
+	chaos, subscription := newChaos(Faults{Duplicate: 1, Corrupt: 1})
+
+	publish(t, chaos, `{"type":"shot"}`)
+
+	for i := 0; i < 2; i++ {
+		if got := receive(t, subscription); got != `{"type"` {
+			t.Fatalf("expected corrupted duplicate, got %q", got)
+		}
+	}
+}
+
+func TestChaosReorder(t *testing.T) {
+	chaos, subscription := newChaos(Faults{Reorder: 1})
+
+	publish(t, chaos, "first")
+	publish(t, chaos, "second")
+
+	if first, second := receive(t, subscription), receive(t, subscription); first != "second" || second != "first" {
+		t.Fatalf("expected reordered messages, got %q then %q", first, second)
+	}
+}
+
+func TestChaosDelay(t *testing.T) {
+	chaos, subscription := newChaos(Faults{Delay: 1, DelayFor: Duration(100 * time.Millisecond)})
+
+	publish(t, chaos, "bang")
+	expectNothing(t, subscription)
+
+	if got := receive(t, subscription); got != "bang" {
+		t.Fatalf("unexpected delayed message %q", got)
+	}
+}
+
+func TestChaosPartition(t *testing.T) {
+	memory := NewMemory()
+	chaos := NewChaos(memory, &ChaosConfig{}, log.New(io.Discard, "", 0))
+
+	inbound := chaos.Subscribe(context.Background(), testTopic)
+	outbound := memory.Subscribe(context.Background(), testTopic)
+
+	chaos.Partition()
+
+	publish(t, chaos, "out")
+	publish(t, memory, "in")
+
+	expectNothing(t, inbound)
+
+	if got := receive(t, outbound); got != "in" {
+		t.Fatalf("expected only the message from the other side, got %q", got)
+	}
+
+	chaos.Heal()
+
+	publish(t, chaos, "healed")
+
+	if got := receive(t, inbound); got != "healed" {
+		t.Fatalf("unexpected message after heal %q", got)
+	}
+
+	if err := inbound.Close(); err != nil {
+		t.Fatalf("unexpected close err: %v", err)
+	}
+
+	if _, ok := <-inbound.Channel(); ok {
+		t.Fatalf("expected the channel to be closed with the subscription")
+	}
+}
+
+func TestParseChaosConfig(t *testing.T) {
+	cfg, err := ParseChaosConfig(`{"topics": {"*": {"drop": 0.5, "delay": 0.1, "delay_for": "300ms"}}, "partition_for": "2s"}`)
+	if err != nil {
+		t.Fatalf("unexpected parse err: %v", err)
+	}
+
+	faults := cfg.Topics[AnyTopic]
+	if faults.Drop != 0.5 || time.Duration(faults.DelayFor) != 300*time.Millisecond || time.Duration(cfg.PartitionFor) != 2*time.Second {
+		t.Fatalf("unexpected chaos config %+v", cfg)
+	}
+
+	if _, err := ParseChaosConfig(`{"partition_for": 2}`); err == nil {
+		t.Fatalf("expected an error for a numeric duration")
+	}
+}
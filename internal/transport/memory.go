@@ -0,0 +1,115 @@
+package transport
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryBuffer is the number of messages a subscriber may lag behind before publishing blocks.
+const memoryBuffer = 256
+
+// Memory is an in-process transport, a stand-in for Redis when the master and the players run in one process.
+type Memory struct {
+	lock        sync.Mutex
+	subscribers map[string]map[*memorySubscription]struct{}
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		subscribers: make(map[string]map[*memorySubscription]struct{}),
+	}
+}
+
+// Publish delivers the payload to every subscriber of the topic, in order.
+func (m *Memory) Publish(ctx context.Context, topic string, payload []byte) error {
+	m.lock.Lock()
+	subscribers := make([]*memorySubscription, 0, len(m.subscribers[topic]))
+	for subscriber := range m.subscribers[topic] {
+		subscribers = append(subscribers, subscriber)
+	}
+	m.lock.Unlock()
+
+	for _, subscriber := range subscribers {
+		msg := &Message{
+			Topic:   topic,
+			Payload: append([]byte(nil), payload...),
+		}
+
+		if err := subscriber.deliver(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) Subscribe(_ context.Context, topic string) Subscription {
+	subscriber := &memorySubscription{
+		memory:   m,
+		topic:    topic,
+		messages: make(chan *Message, memoryBuffer),
+		done:     make(chan struct{}),
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.subscribers[topic] == nil {
+		m.subscribers[topic] = make(map[*memorySubscription]struct{})
+	}
+
+	m.subscribers[topic][subscriber] = struct{}{}
+
+	return subscriber
+}
+
+type memorySubscription struct {
+	memory   *Memory
+	topic    string
+	messages chan *Message
+	done     chan struct{}
+	once     sync.Once
+
+	// lock prevents closing the channel while a message is being delivered.
+	lock   sync.RWMutex
+	closed bool
+}
+
+func (s *memorySubscription) deliver(ctx context.Context, msg *Message) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return nil
+	}
+
+	select {
+	case s.messages <- msg:
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func (s *memorySubscription) Channel() <-chan *Message {
+	return s.messages
+}
+
+func (s *memorySubscription) Close() error {
+	s.once.Do(func() {
+		s.memory.lock.Lock()
+		delete(s.memory.subscribers[s.topic], s)
+		s.memory.lock.Unlock()
+
+		close(s.done)
+
+		s.lock.Lock()
+		s.closed = true
+		close(s.messages)
+		s.lock.Unlock()
+	})
+
+	return nil
+}
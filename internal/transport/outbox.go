@@ -0,0 +1,228 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+const (
+	defaultOutboxSize    = 256
+	defaultOutboxBackoff = 50 * time.Millisecond
+)
+
+// ErrOutboxClosed is returned when publishing through a closed outbox.
+var ErrOutboxClosed = errors.New("outbox closed")
+
+// OutboxStats are the counters of an outbox since it was created.
+type OutboxStats struct {
+	// Queued is the number of messages waiting to be published.
+	Queued int    `json:"queued"`
+	Sent   uint64 `json:"sent"`
+	// Retries counts the failed attempts that were retried.
+	Retries uint64 `json:"retries"`
+	// Dropped counts the messages a full queue lost, Failed the ones that ran out of retries.
+	Dropped uint64 `json:"dropped"`
+	Failed  uint64 `json:"failed"`
+}
+
+// Outbox wraps a transport so that publishing never blocks the caller on the bus: Publish queues the message and
+// a single worker publishes the queue in order, retrying a failed publish with an exponential backoff and jitter.
+// When the queue is full, its drop policy loses the oldest queued message or the new one.
+type Outbox struct {
+	inner  Transport
+	logger *log.Logger
+
+	size    int
+	policy  domain.DropPolicy
+	retries int
+	backoff *backoff
+
+	// ctx stops the worker, the publishers' contexts do not outlive their call to Publish.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wake   chan struct{}
+	done   chan struct{}
+
+	lock   sync.Mutex
+	queue  []*Message
+	closed bool
+
+	sent, retried, dropped, failed uint64
+}
+
+func NewOutbox(inner Transport, cfg *domain.OutboxConfig, logger *log.Logger) *Outbox {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	o := &Outbox{
+		inner:   inner,
+		logger:  logger,
+		size:    cfg.OutboxSize,
+		policy:  cfg.OutboxPolicy,
+		retries: cfg.OutboxRetries,
+		backoff: newBackoff(cfg.OutboxBackoff, cfg.OutboxMaxBackoff, defaultOutboxBackoff),
+		ctx:     ctx,
+		cancel:  cancel,
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	if o.size <= 0 {
+		o.size = defaultOutboxSize
+	}
+
+	if o.policy == "" {
+		o.policy = domain.DropOldest
+	}
+
+	go o.run()
+
+	return o
+}
+
+// Publish queues the payload for the topic. It only fails once the outbox is closed.
+func (o *Outbox) Publish(_ context.Context, topic string, payload []byte) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.closed {
+		return ErrOutboxClosed
+	}
+
+	if len(o.queue) >= o.size {
+		atomic.AddUint64(&o.dropped, 1)
+
+		if o.policy == domain.DropNewest {
+			o.logger.Printf("outbox full, dropping the new message on %s", topic)
+			return nil
+		}
+
+		o.logger.Printf("outbox full, dropping the oldest message on %s", o.queue[0].Topic)
+		o.queue[0] = nil
+		o.queue = o.queue[1:]
+	}
+
+	o.queue = append(o.queue, &Message{Topic: topic, Payload: payload})
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (o *Outbox) Subscribe(ctx context.Context, topic string) Subscription {
+	return o.inner.Subscribe(ctx, topic)
+}
+
+// Stats returns the counters of the outbox.
+func (o *Outbox) Stats() OutboxStats {
+	o.lock.Lock()
+	queued := len(o.queue)
+	o.lock.Unlock()
+
+	return OutboxStats{
+		Queued:  queued,
+		Sent:    atomic.LoadUint64(&o.sent),
+		Retries: atomic.LoadUint64(&o.retried),
+		Dropped: atomic.LoadUint64(&o.dropped),
+		Failed:  atomic.LoadUint64(&o.failed),
+	}
+}
+
+// Close stops accepting messages and waits for the queued ones to be published, until the context is done.
+// The messages still queued then are lost, and the error of the context is returned.
+func (o *Outbox) Close(ctx context.Context) error {
+	o.lock.Lock()
+	o.closed = true
+	o.lock.Unlock()
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+
+	defer o.cancel()
+
+	select {
+	case <-o.done:
+		return nil
+	case <-ctx.Done():
+		o.cancel()
+		<-o.done
+
+		return ctx.Err()
+	}
+}
+
+func (o *Outbox) run() {
+	defer close(o.done)
+
+	for {
+		msg, ok := o.next()
+		if !ok {
+			return
+		}
+
+		o.deliver(msg)
+	}
+}
+
+// next waits for the next queued message. It returns false once the outbox is closed and flushed, or stopped.
+func (o *Outbox) next() (*Message, bool) {
+	for {
+		o.lock.Lock()
+		if len(o.queue) > 0 {
+			msg := o.queue[0]
+			o.queue[0] = nil
+			o.queue = o.queue[1:]
+			o.lock.Unlock()
+
+			return msg, true
+		}
+
+		closed := o.closed
+		o.lock.Unlock()
+
+		if closed {
+			return nil, false
+		}
+
+		select {
+		case <-o.wake:
+		case <-o.ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// deliver publishes the message, retrying up to the configured number of times.
+func (o *Outbox) deliver(msg *Message) {
+	for attempt := 0; ; attempt++ {
+		err := o.inner.Publish(o.ctx, msg.Topic, msg.Payload)
+		if err == nil {
+			atomic.AddUint64(&o.sent, 1)
+			return
+		}
+
+		if attempt >= o.retries || o.ctx.Err() != nil {
+			atomic.AddUint64(&o.failed, 1)
+			o.logger.Printf("publish on %s failed %d times, dropping the message: %v", msg.Topic, attempt+1, err)
+
+			return
+		}
+
+		atomic.AddUint64(&o.retried, 1)
+
+		select {
+		case <-time.After(o.backoff.delay(attempt)):
+		case <-o.ctx.Done():
+		}
+	}
+}
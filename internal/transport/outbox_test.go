@@ -0,0 +1,164 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+var errBlip = errors.New("connection reset")
+
+// flaky fails the first publishes, and blocks them while it is held.
+type flaky struct {
+	Transport
+
+	lock     sync.Mutex
+	failures int
+	held     chan struct{}
+}
+
+func (f *flaky) Publish(ctx context.Context, topic string, payload []byte) error {
+	if f.held != nil {
+		select {
+		case <-f.held:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	f.lock.Lock()
+	failing := f.failures > 0
+	f.failures--
+	f.lock.Unlock()
+
+	if failing {
+		return errBlip
+	}
+
+	return f.Transport.Publish(ctx, topic, payload)
+}
+
+func newOutbox(inner Transport, cfg domain.OutboxConfig) *Outbox {
+	cfg.OutboxBackoff = time.Millisecond
+	cfg.OutboxMaxBackoff = 4 * time.Millisecond
+
+	return NewOutbox(inner, &cfg, log.New(io.Discard, "", 0))
+}
+
+func TestOutboxRetries(t *testing.T) {
+	memory := NewMemory()
+	subscription := memory.Subscribe(context.Background(), testTopic)
+	outbox := newOutbox(&flaky{Transport: memory, failures: 3}, domain.OutboxConfig{OutboxRetries: 3})
+
+	publish(t, outbox, "bang")
+	publish(t, outbox, "bang bang")
+
+	// The blip is over after the retries of the first message, which keeps its place.
+	for _, expected := range []string{"bang", "bang bang"} {
+		if got := receive(t, subscription); got != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	}
+
+	if err := outbox.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close err: %v", err)
+	}
+
+	if stats := outbox.Stats(); stats.Sent != 2 || stats.Retries != 3 || stats.Failed != 0 {
+		t.Fatalf("expected 2 messages sent after 3 retries, got %+v", stats)
+	}
+
+	if err := outbox.Publish(context.Background(), testTopic, []byte("bang")); err != ErrOutboxClosed {
+		t.Fatalf("expected ErrOutboxClosed, got: %v", err)
+	}
+}
+
+func TestOutboxGivesUp(t *testing.T) {
+	memory := NewMemory()
+	subscription := memory.Subscribe(context.Background(), testTopic)
+	outbox := newOutbox(&flaky{Transport: memory, failures: 2}, domain.OutboxConfig{OutboxRetries: 1})
+
+	publish(t, outbox, "lost")
+	publish(t, outbox, "bang")
+
+	if got := receive(t, subscription); got != "bang" {
+		t.Fatalf("expected the next message to be published, got %q", got)
+	}
+
+	if stats := outbox.Stats(); stats.Sent != 1 || stats.Retries != 1 || stats.Failed != 1 {
+		t.Fatalf("expected 1 message failed after 1 retry, got %+v", stats)
+	}
+}
+
+func TestOutboxDropPolicies(t *testing.T) {
+	for policy, expected := range map[domain.DropPolicy][]string{
+		domain.DropOldest: {"first", "third", "fourth"},
+		domain.DropNewest: {"first", "second", "third"},
+	} {
+		memory := NewMemory()
+		subscription := memory.Subscribe(context.Background(), testTopic)
+		inner := &flaky{Transport: memory, held: make(chan struct{})}
+		outbox := newOutbox(inner, domain.OutboxConfig{OutboxSize: 2, OutboxPolicy: policy})
+
+		// The worker holds the first message while the others fill the queue.
+		publish(t, outbox, "first")
+		for outbox.Stats().Queued != 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		for _, payload := range []string{"second", "third", "fourth"} {
+			publish(t, outbox, payload)
+		}
+
+		close(inner.held)
+
+		for _, payload := range expected {
+			if got := receive(t, subscription); got != payload {
+				t.Fatalf("%s: expected %q, got %q", policy, payload, got)
+			}
+		}
+
+		expectNothing(t, subscription)
+
+		if stats := outbox.Stats(); stats.Dropped != 1 || stats.Sent != 3 {
+			t.Fatalf("%s: expected 1 message dropped, got %+v", policy, stats)
+		}
+	}
+}
+
+func TestOutboxCloseFlushes(t *testing.T) {
+	memory := NewMemory()
+	subscription := memory.Subscribe(context.Background(), testTopic)
+	inner := &flaky{Transport: memory, held: make(chan struct{})}
+	outbox := newOutbox(inner, domain.OutboxConfig{})
+
+	publish(t, outbox, "game over")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// A bus that does not come back in time loses the message.
+	if err := outbox.Close(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected the flush to time out, got: %v", err)
+	}
+
+	close(inner.held)
+	expectNothing(t, subscription)
+
+	outbox = newOutbox(memory, domain.OutboxConfig{})
+	publish(t, outbox, "game over")
+
+	if err := outbox.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected close err: %v", err)
+	}
+
+	if got := receive(t, subscription); got != "game over" {
+		t.Fatalf("expected the queued message to be flushed, got %q", got)
+	}
+}
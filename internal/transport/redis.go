@@ -0,0 +1,187 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+const (
+	// redisConfirmTimeout bounds the wait for Redis to confirm a subscription.
+	redisConfirmTimeout = 5 * time.Second
+	// redisHealthCheck is how long a subscription waits for a message before it pings Redis, and then for the
+	// answer before it considers the connection broken.
+	redisHealthCheck = 3 * time.Second
+)
+
+// Redis is the transport over Redis Pub/Sub.
+type Redis struct {
+	client redis.UniversalClient
+}
+
+func NewRedis(client redis.UniversalClient) *Redis {
+	return &Redis{client: client}
+}
+
+// NewRedisClient connects to a single node, a Sentinel failover group or a Cluster depending on the config.
+func NewRedisClient(cfg *domain.RedisConfig) (redis.UniversalClient, error) {
+	options := &redis.UniversalOptions{
+		Addrs:            cfg.RedisAddrs,
+		DB:               cfg.RedisDB,
+		Username:         cfg.RedisUsername,
+		Password:         cfg.RedisPassword,
+		SentinelUsername: cfg.RedisSentinelUser,
+		SentinelPassword: cfg.RedisSentinelPass,
+		MasterName:       cfg.RedisMasterName,
+		DialTimeout:      cfg.RedisDialTimeout,
+		ReadTimeout:      cfg.RedisReadTimeout,
+		WriteTimeout:     cfg.RedisWriteTimeout,
+		PoolSize:         cfg.RedisPoolSize,
+	}
+
+	if cfg.RedisTLS {
+		tlsConfig, err := redisTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		options.TLSConfig = tlsConfig
+	}
+
+	switch {
+	case cfg.RedisMasterName != "" && cfg.RedisCluster:
+		return nil, fmt.Errorf("redis can not be both a sentinel failover group and a cluster")
+	case cfg.RedisMasterName != "":
+		return redis.NewFailoverClient(options.Failover()), nil
+	case cfg.RedisCluster:
+		return redis.NewClusterClient(options.Cluster()), nil
+	case len(cfg.RedisAddrs) > 1:
+		return nil, fmt.Errorf("several redis addresses need REDIS_MASTER_NAME or REDIS_CLUSTER")
+	default:
+		return redis.NewClient(options.Simple()), nil
+	}
+}
+
+func redisTLSConfig(cfg *domain.RedisConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.RedisTLSServerName,
+		InsecureSkipVerify: cfg.RedisTLSInsecure, //nolint:gosec // explicitly requested for test setups
+	}
+
+	if cfg.RedisTLSCA == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(cfg.RedisTLSCA)
+	if err != nil {
+		return nil, fmt.Errorf("read redis CA: %w", err)
+	}
+
+	tlsConfig.RootCAs = x509.NewCertPool()
+	if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in redis CA %s", cfg.RedisTLSCA)
+	}
+
+	return tlsConfig, nil
+}
+
+func (r *Redis) Publish(ctx context.Context, topic string, payload []byte) error {
+	return r.client.Publish(ctx, topic, payload).Err()
+}
+
+// Subscribe waits for Redis to confirm the subscription. The channel of a subscription Redis did not confirm is
+// closed already, like the one of a subscription whose connection broke.
+func (r *Redis) Subscribe(ctx context.Context, topic string) Subscription {
+	subscription := &redisSubscription{
+		pubsub:   r.client.Subscribe(ctx, topic),
+		messages: make(chan *Message),
+		done:     make(chan struct{}),
+	}
+
+	if _, err := subscription.pubsub.ReceiveTimeout(ctx, redisConfirmTimeout); err != nil {
+		_ = subscription.closePubSub()
+		close(subscription.messages)
+
+		return subscription
+	}
+
+	go subscription.forward()
+
+	return subscription
+}
+
+type redisSubscription struct {
+	pubsub   *redis.PubSub
+	messages chan *Message
+	done     chan struct{}
+	once     sync.Once
+
+	// closeOnce closes the Pub/Sub either when the subscription is closed or when its connection broke.
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// forward relays the messages until the subscription is closed or its connection breaks: a receive error, or a
+// ping left unanswered. The client would reconnect on its own, but silently losing the messages in between.
+func (s *redisSubscription) forward() {
+	defer close(s.messages)
+
+	var pinged bool
+	for {
+		received, err := s.pubsub.ReceiveTimeout(context.Background(), redisHealthCheck)
+		if err != nil {
+			var netErr net.Error
+			if pinged || !errors.As(err, &netErr) || !netErr.Timeout() {
+				_ = s.closePubSub()
+				return
+			}
+
+			pinged = true
+			if err := s.pubsub.Ping(context.Background()); err != nil {
+				_ = s.closePubSub()
+				return
+			}
+
+			continue
+		}
+
+		pinged = false
+
+		msg, ok := received.(*redis.Message)
+		if !ok {
+			continue
+		}
+
+		select {
+		case s.messages <- &Message{Topic: msg.Channel, Payload: []byte(msg.Payload)}:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *redisSubscription) Channel() <-chan *Message {
+	return s.messages
+}
+
+func (s *redisSubscription) Close() error {
+	s.once.Do(func() { close(s.done) })
+
+	return s.closePubSub()
+}
+
+func (s *redisSubscription) closePubSub() error {
+	s.closeOnce.Do(func() { s.closeErr = s.pubsub.Close() })
+
+	return s.closeErr
+}
@@ -0,0 +1,136 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+func TestNewRedisClientMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     domain.RedisConfig
+		cluster bool
+	}{
+		{"single node", domain.RedisConfig{RedisAddrs: []string{"redis:6379"}}, false},
+		{"sentinel", domain.RedisConfig{RedisAddrs: []string{"s1:26379", "s2:26379"}, RedisMasterName: "cowboys"}, false},
+		{"cluster", domain.RedisConfig{RedisAddrs: []string{"n1:6379", "n2:6379"}, RedisCluster: true}, true},
+		{"tls", domain.RedisConfig{RedisAddrs: []string{"redis:6379"}, RedisTLS: true}, false},
+	}
+
+	for _, tt := range tests {
+		client, err := NewRedisClient(&tt.cfg)
+		if err != nil {
+			t.Fatalf("%s: unexpected err: %v", tt.name, err)
+		}
+
+		if _, ok := client.(*redis.ClusterClient); ok != tt.cluster {
+			t.Errorf("%s: unexpected %T client", tt.name, client)
+		}
+
+		client.Close()
+	}
+}
+
+func TestNewRedisClientInvalid(t *testing.T) {
+	garbage := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(garbage, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("write CA: %v", err)
+	}
+
+	for name, cfg := range map[string]domain.RedisConfig{
+		"sentinel cluster": {RedisAddrs: []string{"n1:6379"}, RedisMasterName: "cowboys", RedisCluster: true},
+		"several nodes":    {RedisAddrs: []string{"n1:6379", "n2:6379"}},
+		"missing CA":       {RedisAddrs: []string{"redis:6379"}, RedisTLS: true, RedisTLSCA: filepath.Join(t.TempDir(), "none.pem")},
+		"invalid CA":       {RedisAddrs: []string{"redis:6379"}, RedisTLS: true, RedisTLSCA: garbage},
+	} {
+		if _, err := NewRedisClient(&cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// fakeRedis confirms the subscription of the first connection, publishes one message on it and hangs up.
+func fakeRedis(t *testing.T, topic string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		// The SUBSCRIBE command.
+		if _, err := conn.Read(make([]byte, 256)); err != nil {
+			return
+		}
+
+		fmt.Fprintf(conn, "*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:1\r\n", len(topic), topic)
+		fmt.Fprintf(conn, "*3\r\n$7\r\nmessage\r\n$%d\r\n%s\r\n$4\r\nbang\r\n", len(topic), topic)
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestRedisSubscriptionBreaks(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: fakeRedis(t, testTopic), MaxRetries: -1})
+	defer client.Close()
+
+	subscription := NewRedis(client).Subscribe(context.Background(), testTopic)
+	defer subscription.Close()
+
+	if got := receive(t, subscription); got != "bang" {
+		t.Fatalf("unexpected message %q", got)
+	}
+
+	select {
+	case msg, ok := <-subscription.Channel():
+		if ok {
+			t.Fatalf("unexpected message %q", msg.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the subscription to break with its connection")
+	}
+}
+
+func TestRedisSubscriptionUnconfirmed(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	// Nobody answers on a closed port.
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: addr, MaxRetries: -1})
+	defer client.Close()
+
+	subscription := NewRedis(client).Subscribe(context.Background(), testTopic)
+	defer subscription.Close()
+
+	select {
+	case _, ok := <-subscription.Channel():
+		if ok {
+			t.Fatal("expected the unconfirmed subscription to be closed")
+		}
+	default:
+		t.Fatal("expected the unconfirmed subscription to be closed already")
+	}
+}
@@ -0,0 +1,221 @@
+package transport
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+const defaultResubscribeBackoff = 100 * time.Millisecond
+
+// Gap reports that a broken subscription was resubscribed: the messages published on the topic while it was
+// down are lost.
+type Gap struct {
+	Topic string
+	// Lost is how long the subscription was down.
+	Lost time.Duration
+}
+
+// SupervisorStats describe the health of a supervised subscription.
+type SupervisorStats struct {
+	Healthy bool `json:"healthy"`
+	// Down is how long the subscription has been down, 0 while it is healthy.
+	Down   time.Duration `json:"down"`
+	Breaks uint64        `json:"breaks"`
+	Gaps   uint64        `json:"gaps"`
+}
+
+// Supervisor keeps a subscription to a topic alive. The subscriptions of a transport close their channel when
+// their connection breaks, the supervisor then resubscribes with an exponential backoff and jitter, and reports
+// the gap once the subscription is back. Its own channel is only closed by Close.
+type Supervisor struct {
+	inner   Transport
+	topic   string
+	logger  *log.Logger
+	backoff *backoff
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	messages chan *Message
+	gaps     chan Gap
+	done     chan struct{}
+
+	lock sync.Mutex
+	// downSince is when the subscription broke, or when the supervision started until the first subscription.
+	downSince time.Time
+	healthy   bool
+	broken    bool
+	breaks    uint64
+	gapCount  uint64
+}
+
+// Supervise subscribes to the topic and keeps the subscription alive until it is closed.
+func Supervise(inner Transport, topic string, cfg *domain.SubscriptionConfig, logger *log.Logger) *Supervisor {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Supervisor{
+		inner:     inner,
+		topic:     topic,
+		logger:    logger,
+		backoff:   newBackoff(cfg.ResubscribeBackoff, cfg.ResubscribeMaxBackoff, defaultResubscribeBackoff),
+		ctx:       ctx,
+		cancel:    cancel,
+		messages:  make(chan *Message),
+		gaps:      make(chan Gap, 1),
+		done:      make(chan struct{}),
+		downSince: time.Now(),
+	}
+
+	go s.run()
+
+	return s
+}
+
+// Channel returns the channel of the messages received on the topic, over all the subscriptions.
+func (s *Supervisor) Channel() <-chan *Message {
+	return s.messages
+}
+
+// Gaps returns the channel of the gaps. A gap the receiver did not take yet absorbs the next ones.
+func (s *Supervisor) Gaps() <-chan Gap {
+	return s.gaps
+}
+
+// Down returns how long the subscription has been down, 0 while it is healthy.
+func (s *Supervisor) Down() time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.healthy {
+		return 0
+	}
+
+	return time.Since(s.downSince)
+}
+
+// Stats returns the health and the counters of the subscription.
+func (s *Supervisor) Stats() SupervisorStats {
+	down := s.Down()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return SupervisorStats{Healthy: s.healthy, Down: down, Breaks: s.breaks, Gaps: s.gapCount}
+}
+
+// Close stops the supervision and closes the current subscription.
+func (s *Supervisor) Close() error {
+	s.cancel()
+	<-s.done
+
+	return nil
+}
+
+func (s *Supervisor) run() {
+	defer close(s.done)
+	defer close(s.messages)
+
+	for attempt := 0; ; attempt++ {
+		subscription := s.inner.Subscribe(s.ctx, s.topic)
+
+		// A subscription failing right away comes back closed, it does not end the outage.
+		first, open := s.peek(subscription)
+		if open {
+			s.up()
+
+			if s.forward(subscription, first) {
+				s.closeSubscription(subscription)
+				return
+			}
+
+			s.down()
+			attempt = 0
+		}
+
+		s.closeSubscription(subscription)
+
+		select {
+		case <-time.After(s.backoff.delay(attempt)):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// peek takes the message already waiting on a new subscription, if any, and reports whether it is open.
+func (s *Supervisor) peek(subscription Subscription) (*Message, bool) {
+	select {
+	case msg, ok := <-subscription.Channel():
+		return msg, ok
+	default:
+		return nil, true
+	}
+}
+
+// forward relays the messages of the subscription until it breaks, or until the supervision stops, which it
+// reports with true.
+func (s *Supervisor) forward(subscription Subscription, first *Message) bool {
+	msg := first
+	for {
+		if msg != nil {
+			select {
+			case s.messages <- msg:
+			case <-s.ctx.Done():
+				return true
+			}
+		}
+
+		var ok bool
+		select {
+		case msg, ok = <-subscription.Channel():
+			if !ok {
+				return s.ctx.Err() != nil
+			}
+		case <-s.ctx.Done():
+			return true
+		}
+	}
+}
+
+func (s *Supervisor) closeSubscription(subscription Subscription) {
+	if err := subscription.Close(); err != nil && s.ctx.Err() == nil {
+		s.logger.Printf("close broken subscription to %s: %v", s.topic, err)
+	}
+}
+
+// up marks the subscription healthy, reporting the gap if it broke before.
+func (s *Supervisor) up() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.healthy = true
+	if !s.broken {
+		return
+	}
+
+	s.broken = false
+	s.gapCount++
+	gap := Gap{Topic: s.topic, Lost: time.Since(s.downSince)}
+
+	s.logger.Printf("resubscribed to %s after %s", s.topic, gap.Lost.Round(time.Millisecond))
+
+	select {
+	case s.gaps <- gap:
+	default:
+	}
+}
+
+func (s *Supervisor) down() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.logger.Printf("subscription to %s broke, resubscribing", s.topic)
+
+	s.healthy = false
+	s.broken = true
+	s.breaks++
+	s.downSince = time.Now()
+}
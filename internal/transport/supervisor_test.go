@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/reactivejson/cowboys/internal/domain"
+)
+
+// breakable hands out in-memory subscriptions it can break, and closed ones while it is failing.
+type breakable struct {
+	*Memory
+
+	failures      int32
+	lock          sync.Mutex
+	subscriptions []Subscription
+}
+
+func (b *breakable) Subscribe(ctx context.Context, topic string) Subscription {
+	subscription := b.Memory.Subscribe(ctx, topic)
+	if atomic.AddInt32(&b.failures, -1) >= 0 {
+		_ = subscription.Close()
+		return subscription
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.subscriptions = append(b.subscriptions, subscription)
+
+	return subscription
+}
+
+// cut breaks the live subscriptions, the next ones fail the given number of times.
+func (b *breakable) cut(failures int32) {
+	atomic.StoreInt32(&b.failures, failures)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, subscription := range b.subscriptions {
+		_ = subscription.Close()
+	}
+
+	b.subscriptions = nil
+}
+
+func eventually(t *testing.T, condition func() bool, what string) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); !condition(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %s", what)
+		}
+	}
+}
+
+func TestSupervisorResubscribes(t *testing.T) {
+	bus := &breakable{Memory: NewMemory(), failures: 1}
+	supervisor := Supervise(bus, testTopic, &domain.SubscriptionConfig{ResubscribeBackoff: time.Millisecond}, log.New(io.Discard, "", 0))
+
+	eventually(t, func() bool { return supervisor.Down() == 0 }, "the subscription to succeed after a failure")
+	publish(t, bus, "bang")
+
+	if got := receive(t, supervisor); got != "bang" {
+		t.Fatalf("unexpected message %q", got)
+	}
+
+	select {
+	case gap := <-supervisor.Gaps():
+		t.Fatalf("unexpected gap %+v before any break", gap)
+	default:
+	}
+
+	bus.cut(3)
+	eventually(t, func() bool { return supervisor.Down() > 0 }, "the subscription to be down")
+	publish(t, bus, "lost")
+
+	select {
+	case gap := <-supervisor.Gaps():
+		if gap.Topic != testTopic || gap.Lost <= 0 {
+			t.Fatalf("unexpected gap %+v", gap)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no gap reported")
+	}
+
+	publish(t, bus, "bang bang")
+	if got := receive(t, supervisor); got != "bang bang" {
+		t.Fatalf("unexpected message %q", got)
+	}
+
+	if stats := supervisor.Stats(); !stats.Healthy || stats.Breaks != 1 || stats.Gaps != 1 {
+		t.Fatalf("expected 1 break and 1 gap, got %+v", stats)
+	}
+
+	if err := supervisor.Close(); err != nil {
+		t.Fatalf("unexpected close err: %v", err)
+	}
+
+	if _, ok := <-supervisor.Channel(); ok {
+		t.Fatal("expected the channel to be closed")
+	}
+}
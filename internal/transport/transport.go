@@ -0,0 +1,24 @@
+package transport
+
+import (
+	"context"
+)
+
+// Message is a payload received on a topic.
+type Message struct {
+	Topic   string
+	Payload []byte
+}
+
+// Transport is the message bus between the master and the players.
+type Transport interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Subscribe(ctx context.Context, topic string) Subscription
+}
+
+// Subscription delivers the messages published on a topic until it is closed.
+type Subscription interface {
+	// Channel returns the channel of received messages, closed with the subscription.
+	Channel() <-chan *Message
+	Close() error
+}